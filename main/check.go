@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var checkCommandProfiles []string
+var checkCommandComponent string
+var checkCommandOutput string
+
+func init() {
+	checkCommand.Flags().StringSliceVar(&checkCommandProfiles, "profile", []string{}, "")
+	checkCommand.Flags().StringVar(&checkCommandComponent, "component", "", "Component of the project to check.")
+	addOutputFlag(checkCommand, &checkCommandOutput)
+	verCommand.AddCommand(checkCommand)
+}
+
+var checkCommand = &cobra.Command{
+	Use: "check",
+	Run: func(cmd *cobra.Command, args []string) {
+		asJSON := jsonOutput(checkCommandOutput)
+		options, err := vrs.NewDefaultCheckOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Component = checkCommandComponent
+		options.ActiveProfiles = checkCommandProfiles
+		files, err := vrs.Check(options)
+		osexit.ExitOnError(err)
+
+		report := vrs.NewCheckReport(files)
+		if asJSON {
+			printJSON(report)
+		} else {
+			for _, file := range files {
+				if file.Problem == "" {
+					fmt.Printf("%s %s\n", color.GreenString("OK"), file.Name)
+					continue
+				}
+				fmt.Printf("%s %s: %s\n", color.RedString("FAIL"), file.Name, file.Problem)
+			}
+		}
+		if report.Inconsistent > 0 {
+			osexit.ExitBecauseError(fmt.Sprintf("%d of %d synchronized files are inconsistent with the version", report.Inconsistent, len(files)))
+		}
+	},
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var compareCommandComponent string
+var compareCommandAllowEqual bool
+
+func init() {
+	compareCommand.Flags().StringVar(&compareCommandComponent, "component", "", "Component of the project to compare version of.")
+	compareCommand.Flags().BoolVar(&compareCommandAllowEqual, "allow-equal", false, "Succeed if the versions are equal.")
+	verCommand.AddCommand(compareCommand)
+}
+
+// Exits with error unless the first version is newer than the other one. Current version of the project is compared
+// if a single version is given.
+var compareCommand = &cobra.Command{
+	Use:  "compare [VERSION] OTHER",
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			options, err := vrs.NewDefaultReadCurrentOptions()
+			osexit.ExitOnError(err)
+			options.ConfigPath = configPath()
+			options.Component = compareCommandComponent
+			version, err := vrs.ReadCurrentVersion(options)
+			osexit.ExitOnError(err)
+			args = append([]string{version}, args...)
+		}
+		result, err := vrs.Compare(args[0], args[1])
+		osexit.ExitOnError(err)
+
+		switch {
+		case result > 0:
+			fmt.Printf("%s is newer than %s\n", args[0], args[1])
+		case result == 0 && compareCommandAllowEqual:
+			fmt.Printf("%s is equal to %s\n", args[0], args[1])
+		case result == 0:
+			osexit.ExitBecauseError(fmt.Sprintf("%s is equal to %s", args[0], args[1]))
+		default:
+			osexit.ExitBecauseError(fmt.Sprintf("%s is older than %s", args[0], args[1]))
+		}
+	},
+}
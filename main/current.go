@@ -7,15 +7,34 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var currentCommandComponent string
+var currentCommandGitHubOutput bool
+var currentCommandOutput string
+
 func init() {
+	currentCommand.Flags().StringVar(&currentCommandComponent, "component", "", "Component of the project to print version of.")
+	currentCommand.Flags().BoolVar(&currentCommandGitHubOutput, "github-output", false, "Write version to GitHub Actions outputs and environment.")
+	addOutputFlag(currentCommand, &currentCommandOutput)
 	verCommand.AddCommand(currentCommand)
 }
 
 var currentCommand = &cobra.Command{
 	Use: "current",
 	Run: func(cmd *cobra.Command, args []string) {
-		version, err := vrs.ReadCurrentVersion(nil)
+		asJSON := jsonOutput(currentCommandOutput)
+		options, err := vrs.NewDefaultReadCurrentOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Component = currentCommandComponent
+		version, err := vrs.ReadCurrentVersion(options)
 		osexit.ExitOnError(err)
+		if currentCommandGitHubOutput {
+			osexit.ExitOnError(vrs.WriteGitHubOutputs(map[string]string{"version": version}))
+		}
+		if asJSON {
+			printJSON(&vrs.CurrentVersion{Version: version, Component: currentCommandComponent})
+			return
+		}
 
 		fmt.Print(version)
 	},
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var devVersionCommandComponent string
+var devVersionCommandSegment string
+var devVersionCommandIdentifier string
+
+func init() {
+	devVersionCommand.Flags().StringVar(&devVersionCommandComponent, "component", "", "Component of the project to print development version of.")
+	devVersionCommand.Flags().StringVar(&devVersionCommandSegment, "segment", vrs.MinorSegment, "Version segment bumped to compute the version under development.")
+	devVersionCommand.Flags().StringVar(&devVersionCommandIdentifier, "identifier", vrs.DefaultDevIdentifier, "Prerelease identifier of the development version.")
+	verCommand.AddCommand(devVersionCommand)
+}
+
+var devVersionCommand = &cobra.Command{
+	Use: "dev-version",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultDevVersionOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Component = devVersionCommandComponent
+		options.Segment = devVersionCommandSegment
+		options.Identifier = devVersionCommandIdentifier
+		version, err := vrs.DevVersion(options)
+		osexit.ExitOnError(err)
+
+		fmt.Print(version)
+	},
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var doctorCommandRemote string
+var doctorCommandOffline bool
+
+func init() {
+	doctorCommand.Flags().StringVar(&doctorCommandRemote, "remote", "", "Remote repository to verify push access to.")
+	doctorCommand.Flags().BoolVar(&doctorCommandOffline, "offline", false, "Skip verification of push access to the remote repository.")
+	verCommand.AddCommand(doctorCommand)
+}
+
+var doctorCommand = &cobra.Command{
+	Use: "doctor",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultDoctorOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Remote = doctorCommandRemote
+		options.Offline = doctorCommandOffline
+		findings, err := vrs.Doctor(options)
+		osexit.ExitOnError(err)
+
+		failures := 0
+		for _, finding := range findings {
+			if !finding.Failed {
+				fmt.Printf("%s %s: %s\n", color.GreenString("OK"), finding.Check, finding.Message)
+				continue
+			}
+			failures++
+			fmt.Printf("%s %s: %s\n", color.RedString("FAIL"), finding.Check, finding.Message)
+			fmt.Printf("     %s\n", color.YellowString("Fix: %s", finding.Advice))
+		}
+		if failures > 0 {
+			osexit.ExitBecauseError(fmt.Sprintf("%d problems found", failures))
+		}
+	},
+}
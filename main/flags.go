@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+func addGitFlags(command *cobra.Command, gitOptions *vrs.GitOptions) {
+	command.Flags().StringVar(&gitOptions.TagTemplate, "tag-template", "", "Template of the created git tag, for example release-{{.Version}}.")
+	command.Flags().BoolVar(&gitOptions.AnnotateTag, "annotate-tag", false, "Create annotated git tag.")
+	command.Flags().StringVar(&gitOptions.TagMessage, "tag-message", "", "Template of the annotated git tag message.")
+	command.Flags().BoolVar(&gitOptions.TagChangelog, "tag-changelog", false, "Append changelog section of the version to the git tag message.")
+	command.Flags().BoolVar(&gitOptions.SignTag, "sign-tag", false, "Sign created git tag.")
+	command.Flags().StringVar(&gitOptions.TagSigningKey, "tag-signing-key", "", "Key used to sign created git tag.")
+	command.Flags().BoolVar(&gitOptions.SignCommit, "sign-commit", false, "Sign release commit.")
+	command.Flags().StringVar(&gitOptions.CommitSigningKey, "commit-signing-key", "", "Key used to sign release commit.")
+	command.Flags().StringVar(&gitOptions.CommitMessage, "commit-message", "", "Template of the release commit message.")
+	command.Flags().StringVar(&gitOptions.Remote, "remote", "", "Remote repository to push release commit and tag to.")
+	command.Flags().StringVar(&gitOptions.Branch, "branch", "", "Remote branch to push release commit to.")
+	command.Flags().BoolVar(&gitOptions.AllowDirty, "allow-dirty", false, "Allow release when working tree contains uncommitted changes.")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var goCommandOutput string
+var goCommandLdflags bool
+
+func init() {
+	goCommand.Flags().StringVar(&goCommandOutput, "output", vrs.DefaultGoVersionFile, "Path of the generated Go file.")
+	goCommand.Flags().BoolVar(&goCommandLdflags, "ldflags", false, "Print -ldflags value instead of generating the file.")
+	verCommand.AddCommand(goCommand)
+}
+
+var goCommand = &cobra.Command{
+	Use: "go",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultGoVersionOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Output = goCommandOutput
+		if goCommandLdflags {
+			version, err := vrs.ReadGoVersion(options)
+			osexit.ExitOnError(err)
+			fmt.Print(version.Ldflags())
+			return
+		}
+
+		version, err := vrs.GenerateGoVersion(options)
+		osexit.ExitOnError(err)
+		fmt.Printf("Version %s written to %s.\n", color.GreenString(version.Version), goCommandOutput)
+	},
+}
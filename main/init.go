@@ -8,16 +8,33 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var initCommandDryRun bool
+var initCommandGitOptions vrs.GitOptions
+
 func init() {
+	initCommand.Flags().BoolVar(&initCommandDryRun, "dry-run", false, "Print changes which would be made without touching anything.")
+	addGitFlags(initCommand, &initCommandGitOptions)
 	verCommand.AddCommand(initCommand)
 }
 
 var initCommand = &cobra.Command{
 	Use: "init",
 	Run: func(cmd *cobra.Command, args []string) {
-		err := vrs.Init(nil)
+		initOptions, err := vrs.NewDefaultInitOptions()
+		osexit.ExitOnError(err)
+		initOptions.ConfigPath = configPath()
+		initOptions.DryRun = initCommandDryRun
+		initOptions.GitOptions = initCommandGitOptions
+		err = vrs.Init(initOptions)
 		osexit.ExitOnError(err)
+		if initCommandDryRun {
+			return
+		}
 
-		fmt.Printf("Created and commited %s file.\n", color.GreenString(vrs.VrsConfigFileName))
+		configFile := vrs.VrsConfigFileName
+		if verCommandConfig != "" {
+			configFile = verCommandConfig
+		}
+		fmt.Printf("Created and commited %s file.\n", color.GreenString(configFile))
 	},
 }
@@ -3,8 +3,15 @@ package main
 import (
 	"github.com/hekonsek/osexit"
 	"github.com/spf13/cobra"
+	"path/filepath"
 )
 
+var verCommandConfig string
+
+func init() {
+	verCommand.PersistentFlags().StringVar(&verCommandConfig, "config", "", "Path of the config file used instead of vrs.yml.")
+}
+
 var verCommand = &cobra.Command{
 	Use:   "vrs",
 	Short: "vrs - project versioning made easy",
@@ -15,6 +22,17 @@ var verCommand = &cobra.Command{
 	},
 }
 
+// Returns absolute path of the config file selected with the --config flag, so it doesn't depend on the project
+// directory found from the working one.
+func configPath() string {
+	if verCommandConfig == "" {
+		return ""
+	}
+	path, err := filepath.Abs(verCommandConfig)
+	osexit.ExitOnError(err)
+	return path
+}
+
 func main() {
 	osexit.ExitOnError(verCommand.Execute())
 }
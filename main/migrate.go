@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	verCommand.AddCommand(migrateConfigCommand)
+}
+
+var migrateConfigCommand = &cobra.Command{
+	Use: "migrate-config",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultMigrateConfigOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		migrated, err := vrs.MigrateConfig(options)
+		osexit.ExitOnError(err)
+		if migrated {
+			fmt.Printf("Config migrated to version %d.\n", vrs.CurrentConfigVersion)
+		} else {
+			fmt.Printf("Config is in version %d already.\n", vrs.CurrentConfigVersion)
+		}
+	},
+}
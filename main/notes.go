@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+)
+
+var notesCommandTemplateFile string
+var notesCommandOutput string
+
+func init() {
+	notesCommand.Flags().StringVar(&notesCommandTemplateFile, "template", "", "Path of the Go template of the release notes.")
+	notesCommand.Flags().StringVar(&notesCommandOutput, "output", "", "Path of the file the release notes are written to. Notes are printed if empty.")
+	verCommand.AddCommand(notesCommand)
+}
+
+var notesCommand = &cobra.Command{
+	Use: "notes",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultNotesOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		if notesCommandTemplateFile != "" {
+			notesTemplate, err := ioutil.ReadFile(notesCommandTemplateFile)
+			osexit.ExitOnError(err)
+			options.Template = string(notesTemplate)
+		}
+		notes, err := vrs.RenderNotes(options)
+		osexit.ExitOnError(err)
+		if notesCommandOutput == "" {
+			fmt.Print(notes)
+			return
+		}
+
+		err = ioutil.WriteFile(notesCommandOutput, []byte(notes), vrs.DefaultFileMode)
+		osexit.ExitOnError(err)
+	},
+}
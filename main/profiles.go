@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var profilesCommandProfiles []string
+var profilesCommandComponent string
+
+func init() {
+	profilesCommand.Flags().StringSliceVar(&profilesCommandProfiles, "profile", []string{}, "Profiles selected explicitly, as passed to the commands changing the version.")
+	profilesCommand.Flags().StringVar(&profilesCommandComponent, "component", "", "Component of the project to list profiles of.")
+	verCommand.AddCommand(profilesCommand)
+}
+
+var profilesCommand = &cobra.Command{
+	Use: "profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultProfilesOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		options.Component = profilesCommandComponent
+		options.ActiveProfiles = profilesCommandProfiles
+		profiles, err := vrs.ListProfiles(options)
+		osexit.ExitOnError(err)
+
+		for _, profile := range profiles {
+			state := color.New(color.Faint).Sprint("inactive")
+			if profile.Active {
+				state = color.GreenString("active")
+			}
+			fmt.Printf("%s (%s)\n", profile.Name, state)
+			if profile.Sync == nil {
+				continue
+			}
+			for _, file := range profile.Sync.Files {
+				if file.Name != "" {
+					fmt.Printf("  %s\n", file.Name)
+				} else {
+					fmt.Printf("  %s/\n", file.Directory)
+				}
+			}
+		}
+	},
+}
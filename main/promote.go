@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var promoteCommandProfiles []string
+var promoteCommandDryRun bool
+var promoteCommandVerbose bool
+var promoteCommandGitHubOutput bool
+var promoteCommandOutput string
+var promoteCommandGitOptions vrs.GitOptions
+var promoteCommandComponent string
+
+func init() {
+	promoteCommand.Flags().StringSliceVar(&promoteCommandProfiles, "profile", []string{}, "")
+	promoteCommand.Flags().StringVar(&promoteCommandComponent, "component", "", "Component of the project to promote.")
+	promoteCommand.Flags().BoolVar(&promoteCommandDryRun, "dry-run", false, "Print changes which would be made without touching anything.")
+	promoteCommand.Flags().BoolVar(&promoteCommandVerbose, "verbose", false, "Print replacements made in synchronized files.")
+	promoteCommand.Flags().BoolVar(&promoteCommandGitHubOutput, "github-output", false, "Write version, tag and previous-version to GitHub Actions outputs and environment.")
+	addOutputFlag(promoteCommand, &promoteCommandOutput)
+	addGitFlags(promoteCommand, &promoteCommandGitOptions)
+	verCommand.AddCommand(promoteCommand)
+}
+
+var promoteCommand = &cobra.Command{
+	Use: "promote",
+	Run: func(cmd *cobra.Command, args []string) {
+		asJSON := jsonOutput(promoteCommandOutput)
+		promoteOptions, err := vrs.NewDefaultPromoteOptions()
+		osexit.ExitOnError(err)
+		promoteOptions.ConfigPath = configPath()
+		promoteOptions.ActiveProfiles = promoteCommandProfiles
+		promoteOptions.DryRun = promoteCommandDryRun
+		promoteOptions.GitOptions = promoteCommandGitOptions
+		promoteOptions.Component = promoteCommandComponent
+		if asJSON {
+			promoteOptions.DryRunOutput = os.Stderr
+		}
+		result, err := vrs.Promote(promoteOptions)
+		osexit.ExitOnError(err)
+		if promoteCommandGitHubOutput {
+			osexit.ExitOnError(vrs.WriteGitHubOutputs(result.GitHubOutputs()))
+		}
+		if asJSON {
+			printJSON(result)
+			return
+		}
+		printWarnings(result)
+		if promoteCommandVerbose {
+			printSyncReport(result)
+		}
+		if promoteCommandDryRun {
+			return
+		}
+
+		fmt.Printf("Version %s promoted to version %s.\n", color.GreenString(result.OldVersion), color.GreenString(result.NewVersion))
+		printRelease(result)
+	},
+}
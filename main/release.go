@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var releaseCommandProfiles []string
+var releaseCommandSegment string
+var releaseCommandDryRun bool
+var releaseCommandVerbose bool
+var releaseCommandGitHubOutput bool
+var releaseCommandOutput string
+var releaseCommandGitOptions vrs.GitOptions
+
+func init() {
+	releaseCommand.Flags().StringSliceVar(&releaseCommandProfiles, "profile", []string{}, "")
+	releaseCommand.Flags().StringVar(&releaseCommandSegment, "segment", vrs.PatchSegment, "Segment bumped to compute the next development version.")
+	releaseCommand.Flags().BoolVar(&releaseCommandDryRun, "dry-run", false, "Print changes which would be made without touching anything.")
+	releaseCommand.Flags().BoolVar(&releaseCommandVerbose, "verbose", false, "Print replacements made in synchronized files.")
+	releaseCommand.Flags().BoolVar(&releaseCommandGitHubOutput, "github-output", false, "Write version, tag and previous-version to GitHub Actions outputs and environment.")
+	addOutputFlag(releaseCommand, &releaseCommandOutput)
+	addGitFlags(releaseCommand, &releaseCommandGitOptions)
+	verCommand.AddCommand(releaseCommand)
+}
+
+var releaseCommand = &cobra.Command{
+	Use: "release",
+	Run: func(cmd *cobra.Command, args []string) {
+		asJSON := jsonOutput(releaseCommandOutput)
+		releaseOptions, err := vrs.NewDefaultReleaseOptions()
+		osexit.ExitOnError(err)
+		releaseOptions.ConfigPath = configPath()
+		releaseOptions.ActiveProfiles = releaseCommandProfiles
+		releaseOptions.Segment = releaseCommandSegment
+		releaseOptions.DryRun = releaseCommandDryRun
+		releaseOptions.GitOptions = releaseCommandGitOptions
+		if asJSON {
+			releaseOptions.DryRunOutput = os.Stderr
+		}
+		result, err := vrs.Release(releaseOptions)
+		osexit.ExitOnError(err)
+		if releaseCommandGitHubOutput {
+			osexit.ExitOnError(vrs.WriteGitHubOutputs(result.GitHubOutputs()))
+		}
+		if asJSON {
+			printJSON(result)
+			return
+		}
+		printWarnings(result)
+		if releaseCommandVerbose {
+			printSyncReport(result)
+		}
+		if releaseCommandDryRun {
+			return
+		}
+
+		fmt.Printf("Version %s released. Next development version is %s.\n", color.GreenString(result.NewVersion), color.GreenString(result.DevelopmentVersion))
+		printRelease(result)
+	},
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func addOutputFlag(command *cobra.Command, format *string) {
+	command.Flags().StringVar(format, "output", vrs.TextOutputFormat, "Format of the printed report (text or json).")
+}
+
+// Returns whether the report should be printed as JSON instead of human readable text. Exits on unknown format.
+func jsonOutput(format string) bool {
+	osexit.ExitOnError(vrs.ValidateOutputFormat(format))
+	return format == vrs.JSONOutputFormat
+}
+
+func printJSON(report interface{}) {
+	osexit.ExitOnError(vrs.EncodeJSON(os.Stdout, report))
+}
+
+func printSyncReport(result *vrs.BumpResult) {
+	for _, file := range result.SyncedFiles {
+		fmt.Printf("Synced file %s: %s replacements (%+d bytes).\n", file.Name, color.GreenString("%d", file.Replacements), file.SizeDiff)
+	}
+}
+
+func printWarnings(result *vrs.BumpResult) {
+	for _, warning := range result.Warnings {
+		fmt.Printf("%s %s.\n", color.YellowString("Warning:"), warning)
+	}
+}
+
+func printRelease(result *vrs.BumpResult) {
+	if result.ReleaseURL != "" {
+		fmt.Printf("Release published at %s.\n", color.GreenString(result.ReleaseURL))
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+var satisfiesCommandComponent string
+
+func init() {
+	satisfiesCommand.Flags().StringVar(&satisfiesCommandComponent, "component", "", "Component of the project to check version of.")
+	verCommand.AddCommand(satisfiesCommand)
+}
+
+// Exits with error unless the version satisfies the constraint. Current version of the project is checked if no
+// version is given.
+var satisfiesCommand = &cobra.Command{
+	Use:  "satisfies CONSTRAINT [VERSION]",
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			options, err := vrs.NewDefaultReadCurrentOptions()
+			osexit.ExitOnError(err)
+			options.ConfigPath = configPath()
+			options.Component = satisfiesCommandComponent
+			version, err := vrs.ReadCurrentVersion(options)
+			osexit.ExitOnError(err)
+			args = append(args, version)
+		}
+		satisfied, err := vrs.Satisfies(args[1], args[0])
+		osexit.ExitOnError(err)
+
+		if !satisfied {
+			osexit.ExitBecauseError(fmt.Sprintf("%s doesn't satisfy %s", args[1], args[0]))
+		}
+		fmt.Printf("%s satisfies %s\n", args[1], args[0])
+	},
+}
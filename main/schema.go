@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	verCommand.AddCommand(schemaCommand)
+}
+
+var schemaCommand = &cobra.Command{
+	Use: "schema",
+	Run: func(cmd *cobra.Command, args []string) {
+		schema, err := vrs.ConfigSchema()
+		osexit.ExitOnError(err)
+		fmt.Println(string(schema))
+	},
+}
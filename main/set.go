@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var setCommandProfiles []string
+var setCommandDryRun bool
+var setCommandVerbose bool
+var setCommandGitHubOutput bool
+var setCommandOutput string
+var setCommandGitOptions vrs.GitOptions
+var setCommandComponent string
+
+func init() {
+	setCommand.Flags().StringSliceVar(&setCommandProfiles, "profile", []string{}, "")
+	setCommand.Flags().StringVar(&setCommandComponent, "component", "", "Component of the project to set version of.")
+	setCommand.Flags().BoolVar(&setCommandDryRun, "dry-run", false, "Print changes which would be made without touching anything.")
+	setCommand.Flags().BoolVar(&setCommandVerbose, "verbose", false, "Print replacements made in synchronized files.")
+	setCommand.Flags().BoolVar(&setCommandGitHubOutput, "github-output", false, "Write version, tag and previous-version to GitHub Actions outputs and environment.")
+	addOutputFlag(setCommand, &setCommandOutput)
+	addGitFlags(setCommand, &setCommandGitOptions)
+	verCommand.AddCommand(setCommand)
+}
+
+var setCommand = &cobra.Command{
+	Use:  "set VERSION",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		asJSON := jsonOutput(setCommandOutput)
+		setOptions, err := vrs.NewDefaultSetOptions()
+		osexit.ExitOnError(err)
+		setOptions.ConfigPath = configPath()
+		setOptions.ActiveProfiles = setCommandProfiles
+		setOptions.DryRun = setCommandDryRun
+		setOptions.GitOptions = setCommandGitOptions
+		setOptions.Version = args[0]
+		setOptions.Component = setCommandComponent
+		if asJSON {
+			setOptions.DryRunOutput = os.Stderr
+		}
+		result, err := vrs.Set(setOptions)
+		osexit.ExitOnError(err)
+		if setCommandGitHubOutput {
+			osexit.ExitOnError(vrs.WriteGitHubOutputs(result.GitHubOutputs()))
+		}
+		if asJSON {
+			printJSON(result)
+			return
+		}
+		printWarnings(result)
+		if setCommandVerbose {
+			printSyncReport(result)
+		}
+		if setCommandDryRun {
+			return
+		}
+
+		fmt.Printf("Version %s changed to version %s.\n", color.GreenString(result.OldVersion), color.GreenString(result.NewVersion))
+		printRelease(result)
+	},
+}
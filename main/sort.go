@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var sortCommandReverse bool
+var sortCommandIgnoreInvalid bool
+
+func init() {
+	sortCommand.Flags().BoolVar(&sortCommandReverse, "reverse", false, "Print the newest version first.")
+	sortCommand.Flags().BoolVar(&sortCommandIgnoreInvalid, "ignore-invalid", false, "Skip lines which are not versions, for example tags of other kind.")
+	verCommand.AddCommand(sortCommand)
+}
+
+// Reads versions from standard input, one per line, and prints them sorted from the oldest to the newest.
+var sortCommand = &cobra.Command{
+	Use: "sort",
+	Run: func(cmd *cobra.Command, args []string) {
+		var versions []string
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			version := strings.TrimSpace(scanner.Text())
+			if version == "" {
+				continue
+			}
+			if _, err := vrs.Compare(version, version); err != nil && sortCommandIgnoreInvalid {
+				continue
+			}
+			versions = append(versions, version)
+		}
+		osexit.ExitOnError(scanner.Err())
+		err := vrs.Sort(versions)
+		osexit.ExitOnError(err)
+
+		for i := range versions {
+			if sortCommandReverse {
+				i = len(versions) - 1 - i
+			}
+			fmt.Println(versions[i])
+		}
+	},
+}
@@ -6,30 +6,69 @@ import (
 	"github.com/hekonsek/osexit"
 	"github.com/hekonsek/vrs/vrs"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 var upCommandProfiles []string
+var upCommandDryRun bool
+var upCommandVerbose bool
+var upCommandGitHubOutput bool
+var upCommandOutput string
+var upCommandGitOptions vrs.GitOptions
+var upCommandSegment string
+var upCommandPrereleaseIdentifier string
+var upCommandMetadata string
+var upCommandComponent string
 
 func init() {
 	upCommand.Flags().StringSliceVar(&upCommandProfiles, "profile", []string{}, "")
+	upCommand.Flags().StringVar(&upCommandSegment, "segment", vrs.MinorSegment, "Version segment to bump (major, minor, patch, prerelease, revision, position of the segment of numeric version or auto to choose it from Conventional Commits).")
+	upCommand.Flags().StringVar(&upCommandPrereleaseIdentifier, "prerelease-identifier", "", "Prerelease identifier (for example alpha, beta or rc) used when bumping prerelease segment.")
+	upCommand.Flags().StringVar(&upCommandComponent, "component", "", "Component of the project to bump.")
+	upCommand.Flags().StringVar(&upCommandMetadata, "metadata", "", "Build metadata attached to the bumped version.")
+	upCommand.Flags().BoolVar(&upCommandDryRun, "dry-run", false, "Print changes which would be made without touching anything.")
+	upCommand.Flags().BoolVar(&upCommandVerbose, "verbose", false, "Print replacements made in synchronized files.")
+	upCommand.Flags().BoolVar(&upCommandGitHubOutput, "github-output", false, "Write version, tag and previous-version to GitHub Actions outputs and environment.")
+	addOutputFlag(upCommand, &upCommandOutput)
+	addGitFlags(upCommand, &upCommandGitOptions)
 	verCommand.AddCommand(upCommand)
 }
 
 var upCommand = &cobra.Command{
 	Use: "up",
 	Run: func(cmd *cobra.Command, args []string) {
-		oldVersion, err := vrs.ReadCurrentVersion(nil)
-		osexit.ExitOnError(err)
-
+		asJSON := jsonOutput(upCommandOutput)
 		bumpOptions, err := vrs.NewDefaultBumpOptions()
 		osexit.ExitOnError(err)
+		bumpOptions.ConfigPath = configPath()
 		bumpOptions.ActiveProfiles = upCommandProfiles
-		err = vrs.Bump(bumpOptions)
-		osexit.ExitOnError(err)
-
-		newVersion, err := vrs.ReadCurrentVersion(nil)
+		bumpOptions.DryRun = upCommandDryRun
+		bumpOptions.GitOptions = upCommandGitOptions
+		bumpOptions.Segment = upCommandSegment
+		bumpOptions.PrereleaseIdentifier = upCommandPrereleaseIdentifier
+		bumpOptions.Metadata = upCommandMetadata
+		bumpOptions.Component = upCommandComponent
+		if asJSON {
+			bumpOptions.DryRunOutput = os.Stderr
+		}
+		result, err := vrs.Bump(bumpOptions)
 		osexit.ExitOnError(err)
+		if upCommandGitHubOutput {
+			osexit.ExitOnError(vrs.WriteGitHubOutputs(result.GitHubOutputs()))
+		}
+		if asJSON {
+			printJSON(result)
+			return
+		}
+		printWarnings(result)
+		if upCommandVerbose {
+			printSyncReport(result)
+		}
+		if upCommandDryRun {
+			return
+		}
 
-		fmt.Printf("Version %s bumped to version %s.\n", color.GreenString(oldVersion), color.GreenString(newVersion))
+		fmt.Printf("Version %s bumped to version %s.\n", color.GreenString(result.OldVersion), color.GreenString(result.NewVersion))
+		printRelease(result)
 	},
 }
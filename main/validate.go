@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/hekonsek/osexit"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	verCommand.AddCommand(validateConfigCommand)
+}
+
+var validateConfigCommand = &cobra.Command{
+	Use: "validate-config",
+	Run: func(cmd *cobra.Command, args []string) {
+		options, err := vrs.NewDefaultValidateConfigOptions()
+		osexit.ExitOnError(err)
+		options.ConfigPath = configPath()
+		problems, err := vrs.ValidateConfig(options)
+		osexit.ExitOnError(err)
+		if len(problems) == 0 {
+			fmt.Println("Config is valid.")
+			return
+		}
+
+		for _, problem := range problems {
+			fmt.Printf("%s %s\n", color.RedString("Error:"), problem)
+		}
+		osexit.ExitBecauseError(fmt.Sprintf("config has %d problems", len(problems)))
+	},
+}
@@ -0,0 +1,77 @@
+package vrs
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Environment variables of CI servers holding the number of the build, checked in order.
+var DefaultBuildNumberVariables = []string{"GITHUB_RUN_NUMBER", "BUILD_NUMBER"}
+
+// Number of CI build appended to the version written into sync files. The version in vrs.yml is not changed.
+type BuildNumber struct {
+	// Environment variables the build number is read from. The first one set is used. Defaults to
+	// DefaultBuildNumberVariables.
+	Env []string `yaml:",omitempty"`
+	// Appends the build number as an additional segment of the version (1.2.3.42) instead of build metadata
+	// (1.2.3+42).
+	Segment bool `yaml:",omitempty"`
+}
+
+// Returns the version synced into files with the build number appended, if it has been configured and the build
+// number is set in the environment.
+func (config *VrsConfig) withBuildNumber(version string) (string, error) {
+	if config.BuildNumber == nil {
+		return version, nil
+	}
+	variables := config.BuildNumber.Env
+	if len(variables) == 0 {
+		variables = DefaultBuildNumberVariables
+	}
+	var variable, number string
+	for _, variable = range variables {
+		if number = os.Getenv(variable); number != "" {
+			break
+		}
+	}
+	if number == "" {
+		return version, nil
+	}
+
+	if config.BuildNumber.Segment {
+		if !isNumeric(number) {
+			return "", fmt.Errorf("build number %q of %s variable is not a number", number, variable)
+		}
+		coreEnd := strings.IndexAny(version, "-+")
+		if coreEnd < 0 {
+			coreEnd = len(version)
+		}
+		return version[:coreEnd] + "." + number + version[coreEnd:], nil
+	}
+	err := validateMetadata(number)
+	if err != nil {
+		return "", fmt.Errorf("build number %q of %s variable is invalid build metadata: %s", number, variable, err)
+	}
+	if strings.Contains(version, "+") {
+		return version + "." + number, nil
+	}
+	return version + "+" + number, nil
+}
+
+// Returns pattern matching the synced version with any build number appended by withBuildNumber, so files stamped by
+// previous builds are synced as well. Empty pattern is returned if the build number is not configured.
+func (buildNumber *BuildNumber) versionPattern(version string) string {
+	if buildNumber == nil {
+		return ""
+	}
+	if buildNumber.Segment {
+		core := versionCore(version)
+		return regexp.QuoteMeta(core) + `(?:\.[0-9]+)?` + regexp.QuoteMeta(version[len(core):])
+	}
+	if strings.Contains(version, "+") {
+		return regexp.QuoteMeta(version) + `(?:\.[0-9A-Za-z-]+)?`
+	}
+	return regexp.QuoteMeta(version) + `(?:\+[0-9A-Za-z-]+)?`
+}
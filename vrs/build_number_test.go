@@ -0,0 +1,83 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSyncBuildNumberAsMetadata(t *testing.T) {
+	// Given
+	setenv(t, "GITHUB_RUN_NUMBER", "")
+	setenv(t, "BUILD_NUMBER", "42")
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":      []byte("version: 1.0.0\nbuildNumber: {}\nsync:\n  files:\n  - name: package.json\n    type: json\n    path: $.version\n"),
+		"package.json": []byte(`{"version": "1.0.0"}`),
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+	assert.Equal(t, `{"version": "1.1.0+42"}`, readInMemory(t, fileSystem, "package.json"))
+	assert.Contains(t, readInMemory(t, fileSystem, "vrs.yml"), "version: 1.1.0\n")
+}
+
+func TestSyncBuildNumberAsSegment(t *testing.T) {
+	// Given
+	setenv(t, "CI_BUILD", "7")
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nbuildNumber:\n  env: [CI_BUILD]\n  segment: true\nsync:\n  files:\n" +
+			"  - name: AssemblyInfo.cs\n    pattern: 'AssemblyVersion\\(\"[^\"]*\"\\)'\n    replacement: 'AssemblyVersion(\"{{.Version}}\")'\n"),
+		"AssemblyInfo.cs": []byte(`[assembly: AssemblyVersion("1.0.0.3")]`),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.PatchSegment, FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, `[assembly: AssemblyVersion("1.0.1.7")]`, readInMemory(t, fileSystem, "AssemblyInfo.cs"))
+}
+
+func TestSyncTextStampedByPreviousBuild(t *testing.T) {
+	// Given
+	setenv(t, "GITHUB_RUN_NUMBER", "")
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.2.0\nbuildNumber: {}\nsync:\n  files:\n  - name: VERSION\n" +
+			"  - name: build.txt\n    replacement: 'Build {{.Version}}'\n"),
+		"VERSION":   []byte("1.2.0\n"),
+		"build.txt": []byte("Build 1.2.0\n"),
+	})
+
+	// When
+	for _, number := range []string{"42", "43"} {
+		setenv(t, "BUILD_NUMBER", number)
+		_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.PatchSegment, FileSystem: fileSystem})
+		assert.NoError(t, err)
+	}
+
+	// Then
+	assert.Equal(t, "1.2.2+43\n", readInMemory(t, fileSystem, "VERSION"))
+	assert.Equal(t, "Build 1.2.2+43\n", readInMemory(t, fileSystem, "build.txt"))
+}
+
+func TestSyncTextStampedByPreviousBuildAsSegment(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.2.0-rc.1\nbuildNumber:\n  env: [CI_BUILD]\n  segment: true\nsync:\n  files:\n  - name: VERSION\n"),
+		"VERSION": []byte("1.2.0-rc.1"),
+	})
+
+	// When
+	for _, number := range []string{"7", "8"} {
+		setenv(t, "CI_BUILD", number)
+		_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.PrereleaseSegment, FileSystem: fileSystem})
+		assert.NoError(t, err)
+	}
+
+	// Then
+	assert.Equal(t, "1.2.0.8-rc.3", readInMemory(t, fileSystem, "VERSION"))
+}
@@ -0,0 +1,193 @@
+package vrs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DefaultCalVerFormat = "YYYY.0M.MICRO"
+
+const calVerMicroToken = "MICRO"
+
+// Tokens are ordered so that longer tokens are matched first.
+var calVerTokens = []string{"YYYY", calVerMicroToken, "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D"}
+
+var calVerTokenExpressions = map[string]string{
+	"YYYY":           `\d{4}`,
+	"YY":             `\d{1,3}`,
+	"0Y":             `\d{2,3}`,
+	"MM":             `\d{1,2}`,
+	"0M":             `\d{2}`,
+	"WW":             `\d{1,2}`,
+	"0W":             `\d{2}`,
+	"DD":             `\d{1,2}`,
+	"0D":             `\d{2}`,
+	calVerMicroToken: `\d+`,
+}
+
+// Calendar versioning scheme (https://calver.org). Format consists of tokens (YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D,
+// MICRO) separated by literal characters, for example YYYY.0M.MICRO renders versions like 2024.06.1. Bump kind is
+// ignored as the next version is always computed from the date.
+type CalVer struct {
+	Format string
+	// Date used to compute the next version. Zero value means current time.
+	Date time.Time
+}
+
+type calVerFormatPart struct {
+	token   string
+	literal string
+}
+
+func (calver *CalVer) Next(current string, kind BumpKind) (string, error) {
+	date := calver.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	parts, expression, err := calver.compile()
+	if err != nil {
+		return "", err
+	}
+	currentValues, err := calver.match(parts, expression, current)
+	if err != nil {
+		return "", err
+	}
+
+	// Weeks are rendered together with the year the week belongs to, so that 2024-12-30 is 2025.01 and not 2024.01.
+	weekBased := false
+	for _, part := range parts {
+		if part.token == "WW" || part.token == "0W" {
+			weekBased = true
+		}
+	}
+
+	sameDate := true
+	for i, part := range parts {
+		if part.token != "" && part.token != calVerMicroToken && renderCalVerToken(part.token, date, weekBased) != currentValues[i] {
+			sameDate = false
+		}
+	}
+	if sameDate && !strings.Contains(calver.format(), calVerMicroToken) {
+		return "", fmt.Errorf("version %s has been already released for the current date and format %s has no %s token", current, calver.format(), calVerMicroToken)
+	}
+
+	next := ""
+	for i, part := range parts {
+		switch part.token {
+		case "":
+			next += part.literal
+		case calVerMicroToken:
+			micro := 0
+			if sameDate {
+				currentMicro, err := strconv.Atoi(currentValues[i])
+				if err != nil {
+					return "", err
+				}
+				micro = currentMicro + 1
+			}
+			next += strconv.Itoa(micro)
+		default:
+			next += renderCalVerToken(part.token, date, weekBased)
+		}
+	}
+	return next, nil
+}
+
+func (calver *CalVer) Validate(version string) error {
+	parts, expression, err := calver.compile()
+	if err != nil {
+		return err
+	}
+	_, err = calver.match(parts, expression, version)
+	return err
+}
+
+func (calver *CalVer) format() string {
+	if calver.Format == "" {
+		return DefaultCalVerFormat
+	}
+	return calver.Format
+}
+
+func (calver *CalVer) compile() ([]calVerFormatPart, *regexp.Regexp, error) {
+	format := calver.format()
+	var parts []calVerFormatPart
+	expression := "^"
+	hasToken := false
+	for len(format) > 0 {
+		token := ""
+		for _, candidate := range calVerTokens {
+			if strings.HasPrefix(format, candidate) {
+				token = candidate
+				break
+			}
+		}
+		if token == "" {
+			parts = append(parts, calVerFormatPart{literal: format[:1]})
+			expression += regexp.QuoteMeta(format[:1])
+			format = format[1:]
+			continue
+		}
+		hasToken = true
+		parts = append(parts, calVerFormatPart{token: token})
+		expression += "(" + calVerTokenExpressions[token] + ")"
+		format = format[len(token):]
+	}
+	if !hasToken {
+		return nil, nil, fmt.Errorf("calver format %q contains no tokens", calver.format())
+	}
+	r, err := regexp.Compile(expression + "$")
+	if err != nil {
+		return nil, nil, err
+	}
+	return parts, r, nil
+}
+
+// Returns values of the version matched by the format parts. Literal parts have empty values.
+func (calver *CalVer) match(parts []calVerFormatPart, expression *regexp.Regexp, version string) ([]string, error) {
+	matches := expression.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("%w %q: expected calver format %s", ErrInvalidVersion, version, calver.format())
+	}
+	values := make([]string, len(parts))
+	group := 1
+	for i, part := range parts {
+		if part.token != "" {
+			values[i] = matches[group]
+			group++
+		}
+	}
+	return values, nil
+}
+
+// Renders token for given date. ISO week-numbering year is used for year tokens if weekBased is true.
+func renderCalVerToken(token string, date time.Time, weekBased bool) string {
+	year, week := date.ISOWeek()
+	if !weekBased {
+		year = date.Year()
+	}
+	switch token {
+	case "YYYY":
+		return strconv.Itoa(year)
+	case "YY":
+		return strconv.Itoa(year - 2000)
+	case "0Y":
+		return fmt.Sprintf("%02d", year-2000)
+	case "MM":
+		return strconv.Itoa(int(date.Month()))
+	case "0M":
+		return fmt.Sprintf("%02d", int(date.Month()))
+	case "WW":
+		return strconv.Itoa(week)
+	case "0W":
+		return fmt.Sprintf("%02d", week)
+	case "DD":
+		return strconv.Itoa(date.Day())
+	case "0D":
+		return fmt.Sprintf("%02d", date.Day())
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestCalVerNextForNewMonth(t *testing.T) {
+	// Given
+	calver := &vrs.CalVer{Format: "YYYY.0M.MICRO", Date: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)}
+
+	// When
+	version, err := calver.Next("2024.05.3", vrs.MinorSegment)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2024.06.0", version)
+}
+
+func TestCalVerNextForSameMonth(t *testing.T) {
+	// Given
+	calver := &vrs.CalVer{Format: "YYYY.0M.MICRO", Date: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)}
+
+	// When
+	version, err := calver.Next("2024.06.0", vrs.MinorSegment)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2024.06.1", version)
+}
+
+func TestCalVerNextWithoutMicro(t *testing.T) {
+	// Given
+	calver := &vrs.CalVer{Format: "0Y.0M.0D", Date: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)}
+
+	// When
+	version, err := calver.Next("24.06.14", vrs.MinorSegment)
+	assert.NoError(t, err)
+	_, sameDayErr := calver.Next(version, vrs.MinorSegment)
+
+	// Then
+	assert.Equal(t, "24.06.15", version)
+	assert.Error(t, sameDayErr)
+}
+
+func TestCalVerNextUsesWeekYear(t *testing.T) {
+	// Given
+	calver := &vrs.CalVer{Format: "YYYY.0W.MICRO", Date: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)}
+
+	// When
+	version, err := calver.Next("2024.52.0", vrs.MinorSegment)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2025.01.0", version)
+}
+
+func TestCalVerValidate(t *testing.T) {
+	// Given
+	calver := &vrs.CalVer{}
+
+	// Then
+	assert.NoError(t, calver.Validate("2024.06.1"))
+	assert.Error(t, calver.Validate("2024.6.1"))
+	assert.Error(t, calver.Validate("1.2.3"))
+}
+
+func TestCalVerBump(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "2024.06.0", Scheme: vrs.CalVerScheme}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Date: time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024.06.1", version)
+}
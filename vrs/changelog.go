@@ -0,0 +1,190 @@
+package vrs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const DefaultChangelogFile = "CHANGELOG.md"
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).
+`
+
+// Generates changelog in Keep a Changelog format (https://keepachangelog.com) from the commits made since the last
+// release tag.
+type Changelog struct {
+	// Path of the changelog relative to the project directory, CHANGELOG.md by default.
+	File string `yaml:",omitempty"`
+}
+
+// Groups of the changelog section, in order of appearance.
+var changelogGroups = []string{"Added", "Changed", "Fixed"}
+
+// Returns group of the changelog the commit belongs to and its description. Empty group is returned for commits left
+// out of the changelog, like documentation changes or merges.
+func changelogGroup(entry *GitLogEntry) (string, string) {
+	commit, ok := ParseConventionalCommit(entry.Subject, entry.Body)
+	if !ok {
+		if strings.HasPrefix(entry.Subject, "Merge ") {
+			return "", ""
+		}
+		return "Changed", entry.Subject
+	}
+	description := commit.Description
+	if commit.Scope != "" {
+		description = "**" + commit.Scope + ":** " + description
+	}
+	switch {
+	case commit.Breaking:
+		return "Changed", "**BREAKING:** " + description
+	case commit.Type == "feat":
+		return "Added", description
+	case commit.Type == "fix":
+		return "Fixed", description
+	case commit.Type == "perf" || commit.Type == "refactor" || commit.Type == "revert":
+		return "Changed", description
+	}
+	return "", ""
+}
+
+// Renders changelog section of the version, for example "## [1.2.0] - 2021-03-04" followed by the commits grouped by
+// their kind.
+func renderChangelogSection(version string, date time.Time, commits []*GitLogEntry) string {
+	entries := map[string][]string{}
+	for _, commit := range commits {
+		group, description := changelogGroup(commit)
+		if group != "" {
+			entries[group] = append(entries[group], description)
+		}
+	}
+	section := &strings.Builder{}
+	fmt.Fprintf(section, "## [%s] - %s\n", version, date.Format("2006-01-02"))
+	for _, group := range changelogGroups {
+		if len(entries[group]) == 0 {
+			continue
+		}
+		fmt.Fprintf(section, "\n### %s\n\n", group)
+		for _, description := range entries[group] {
+			fmt.Fprintf(section, "- %s\n", description)
+		}
+	}
+	return section.String()
+}
+
+// Inserts section above the sections of previous releases. Unreleased section is kept on top.
+func insertChangelogSection(changelog []byte, section string) []byte {
+	if len(bytes.TrimSpace(changelog)) == 0 {
+		changelog = []byte(changelogHeader)
+	}
+	lines := bytes.SplitAfter(changelog, []byte("\n"))
+	offset := 0
+	for _, line := range lines {
+		if bytes.HasPrefix(line, []byte("## ")) && !bytes.HasPrefix(bytes.ToLower(line), []byte("## [unreleased]")) {
+			break
+		}
+		offset += len(line)
+	}
+	updated := append([]byte{}, changelog[:offset]...)
+	if len(updated) > 0 && !bytes.HasSuffix(updated, []byte("\n\n")) {
+		if !bytes.HasSuffix(updated, []byte("\n")) {
+			updated = append(updated, '\n')
+		}
+		updated = append(updated, '\n')
+	}
+	updated = append(updated, section...)
+	if offset < len(changelog) {
+		updated = append(updated, '\n')
+	}
+	return append(updated, changelog[offset:]...)
+}
+
+// Returns commits made since the last release tag.
+func (config *VrsConfig) unreleasedCommits(executor *executor, gitOptions *GitOptions) ([]*GitLogEntry, error) {
+	pattern, err := config.tagPattern(executor, gitOptions)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := executor.git.LastTag(executor.ctx, "", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return executor.git.Log(executor.ctx, tag, "")
+}
+
+// Adds section of the released version to the changelog. Returns name of the changelog file or empty string if the
+// changelog is not generated.
+func (config *VrsConfig) writeChangelog(executor *executor, gitOptions *GitOptions) (string, error) {
+	if config.Changelog == nil {
+		return "", nil
+	}
+	commits, err := config.unreleasedCommits(executor, gitOptions)
+	if err != nil {
+		return "", fmt.Errorf("cannot read commits of the changelog: %w", err)
+	}
+	file := firstNonEmpty(config.Changelog.File, DefaultChangelogFile)
+	changelog, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, file))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	section := renderChangelogSection(config.tagVersion(), time.Now(), commits)
+	return file, executor.writeFile(file, preserveLineEndings(changelog, insertChangelogSection(changelog, section)))
+}
+
+var unreleasedHeading = regexp.MustCompile(`(?mi)^## \[unreleased\][^\n]*\n`)
+
+var unreleasedLink = regexp.MustCompile(`(?mi)^\[unreleased\]: *(\S+)[^\n]*\n?`)
+
+// Moves the content of [Unreleased] section of Keep a Changelog file into the section of the new version. Comparison
+// link of the unreleased changes (https://github.com/org/app/compare/v1.0.0...HEAD) is moved to the new version and
+// the link of the new version is added.
+func changelogUpdater(oldVersion string, newVersion string, date time.Time) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		heading := unreleasedHeading.FindIndex(content)
+		if heading == nil {
+			return nil, 0, fmt.Errorf("changelog has no [Unreleased] section")
+		}
+		spans := [][2]int{{heading[1], heading[1]}}
+		values := [][]byte{[]byte(fmt.Sprintf("\n## [%s] - %s\n", newVersion, date.Format("2006-01-02")))}
+
+		link := unreleasedLink.FindSubmatchIndex(content)
+		if link != nil && link[0] >= heading[1] {
+			unreleasedURL, versionURL, ok := rollOverComparisonLink(string(content[link[2]:link[3]]), oldVersion, newVersion)
+			if ok {
+				versionLink := fmt.Sprintf("[%s]: %s\n", newVersion, versionURL)
+				if link[1] == len(content) && !bytes.HasSuffix(content, []byte("\n")) {
+					versionLink = "\n" + strings.TrimSuffix(versionLink, "\n")
+				}
+				spans = append(spans, [2]int{link[2], link[3]}, [2]int{link[1], link[1]})
+				values = append(values, []byte(unreleasedURL), []byte(versionLink))
+			}
+		}
+		return replaceSpans(content, spans, values), 1, nil
+	}
+}
+
+// Returns comparison links of unreleased changes and of the new version, given the link of unreleased changes of the
+// old version. For example .../compare/v1.0.0...HEAD gives .../compare/v1.1.0...HEAD and .../compare/v1.0.0...v1.1.0.
+func rollOverComparisonLink(url string, oldVersion string, newVersion string) (string, string, bool) {
+	headIndex := strings.LastIndex(url, "HEAD")
+	if headIndex < 0 {
+		return "", "", false
+	}
+	versionIndex := strings.LastIndex(url[:headIndex], oldVersion)
+	if versionIndex < 0 {
+		return "", "", false
+	}
+	refIndex := strings.LastIndex(url[:versionIndex], "/") + 1
+	newRef := url[refIndex:versionIndex] + newVersion
+	unreleasedURL := url[:refIndex] + newRef + url[versionIndex+len(oldVersion):]
+	versionURL := url[:headIndex] + newRef + url[headIndex+len("HEAD"):]
+	return unreleasedURL, versionURL, true
+}
@@ -0,0 +1,119 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+)
+
+type CheckOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to check. Empty name selects the project itself.
+	Component      string
+	ActiveProfiles []string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Client used to read the current branch activating profiles. Defaults to GoGitClient reading the repository of
+	// the project directory.
+	GitClient GitClient
+}
+
+func NewDefaultCheckOptions() (*CheckOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &CheckOptions{Basedir: basedir, UserConfigFile: defaultUserConfigFile()}, nil
+}
+
+// Sync file verified by Check.
+type CheckedFile struct {
+	Name string `json:"name"`
+	// Description of the inconsistency of the file. Empty if the file contains the current version.
+	Problem string `json:"problem,omitempty"`
+}
+
+// Verifies that every synchronized file contains the current version from the config, for example to detect manual
+// edits in CI. File is consistent if synchronizing it with the current version wouldn't change it. Problems of the
+// files are reported in the returned files rather than as an error.
+func Check(options *CheckOptions) ([]*CheckedFile, error) {
+	if options == nil {
+		o, err := NewDefaultCheckOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
+	if err != nil {
+		return nil, err
+	}
+	executor := newExecutor(context.Background(), options.Basedir, false, nil, options.GitClient, options.FileSystem, nil)
+	activeProfiles := config.activeProfiles(executor, options.ActiveProfiles)
+	executor.startBump(config.Version, config.Version, activeProfiles)
+	version := config.syncVersion(config.Version)
+
+	var files []*CheckedFile
+	for _, sync := range config.activeSyncs(activeProfiles) {
+		for i := range sync.Files {
+			file := &sync.Files[i]
+			namePattern, err := file.namePattern()
+			if err != nil {
+				return nil, err
+			}
+			names, err := expandSyncFileName(executor, namePattern, sync.Exclude)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				problem, err := checkFile(executor, file, name, version)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, &CheckedFile{Name: name, Problem: problem})
+			}
+		}
+	}
+	return files, nil
+}
+
+// Returns inconsistency of the synchronized file or empty string if the file contains the version.
+func checkFile(executor *executor, file *SyncFile, name string, version string) (string, error) {
+	content, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, name))
+	if os.IsNotExist(err) {
+		return "file is missing", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	update, err := file.updater(executor, name, version, version)
+	if err != nil {
+		return "", err
+	}
+	updated, replacements, err := update(content)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if !file.definesContent() {
+		updated = preserveLineEndings(content, updated)
+	}
+	switch {
+	case replacements == 0 && file.Path != "":
+		return fmt.Sprintf("%s not found", file.Path), nil
+	case replacements == 0 && file.Pattern != "":
+		return fmt.Sprintf("pattern %s not matched", file.Pattern), nil
+	case replacements == 0:
+		return fmt.Sprintf("version %s not found", version), nil
+	case !bytes.Equal(updated, content):
+		return fmt.Sprintf("contains other version than %s", version), nil
+	}
+	return "", nil
+}
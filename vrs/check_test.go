@@ -0,0 +1,33 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckSyncFiles(t *testing.T) {
+	// Given
+	config := "version: 1.2.0\nsync:\n  files:\n  - name: VERSION\n  - name: package.json\n    type: json\n    path: $.version\n" +
+		"  - name: Dockerfile\n    pattern: '\\d+\\.\\d+\\.\\d+'\n  - name: README\n  - name: CHANGES\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":      []byte(config),
+		"VERSION":      []byte("1.2.0"),
+		"package.json": []byte(`{"version": "1.2.0"}`),
+		"Dockerfile":   []byte("FROM app:1.1.0\n"),
+		"README":       []byte("Install the latest version.\n"),
+	})
+
+	// When
+	files, err := vrs.Check(&vrs.CheckOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []*vrs.CheckedFile{
+		{Name: "VERSION"},
+		{Name: "package.json"},
+		{Name: "Dockerfile", Problem: "contains other version than 1.2.0"},
+		{Name: "README", Problem: "version 1.2.0 not found"},
+		{Name: "CHANGES", Problem: "file is missing"},
+	}, files)
+}
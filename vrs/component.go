@@ -0,0 +1,194 @@
+package vrs
+
+import (
+	"fmt"
+	"path"
+)
+
+// Default template of the tags of components, for example api/v1.2.0. Tags of different components don't collide.
+const DefaultComponentTagTemplate = "{{.Component}}/v{{.Version}}"
+
+// Independently versioned part of the project, for example a module of monorepo. Component is declared either inline,
+// with its own version, sync files and tag, or by the path of the directory containing its own vrs.yml.
+type Component struct {
+	Name string
+	// Directory of the component containing its own vrs.yml, relative to the project directory. Other settings of the
+	// component are read from that file.
+	Path    string `yaml:",omitempty"`
+	Version string `yaml:",omitempty"`
+	// Files synchronized with the version of the component, relative to the project directory.
+	Sync *Sync `yaml:",omitempty"`
+	// Tag of the component. Tags of the project are named with DefaultComponentTagTemplate by default.
+	Tag *Tag `yaml:",omitempty"`
+	// Names of the components this one depends on. Bump of any of them bumps the patch segment of this component too.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+}
+
+// Component bumped together with the component it depends on.
+type dependentBump struct {
+	config     *VrsConfig
+	oldVersion string
+	result     *BumpResult
+}
+
+// Parses config of the project or of its component, if the component name is not empty.
+func parseComponentConfig(fileSystem FileSystem, basedir string, configPath string, userConfigFile string, name string) (*VrsConfig, error) {
+	config, err := parseConfigFile(fileSystem, basedir, configPath, userConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return config.selectComponent(fileSystem, basedir, name)
+}
+
+// Returns config of the component with given name. Empty name selects the project itself.
+func (config *VrsConfig) selectComponent(fileSystem FileSystem, basedir string, name string) (*VrsConfig, error) {
+	if name == "" {
+		if config.Version == "" {
+			return nil, fmt.Errorf("project has no version of its own: select one of its components")
+		}
+		return config, nil
+	}
+
+	var component *Component
+	for _, candidate := range config.Components {
+		if candidate.Name == name {
+			component = candidate
+			break
+		}
+	}
+	if component == nil {
+		return nil, fmt.Errorf("unknown component %q", name)
+	}
+	if component.Path != "" {
+		source, err := ParseVersioonConfigFS(fileSystem, path.Join(basedir, component.Path))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read config of component %s: %w", name, err)
+		}
+		source.file = path.Join(component.Path, source.configFile())
+		// Files of the component are relative to its directory, while operations are executed in the project
+		// directory.
+		componentConfig := *source
+		componentConfig.Sync = source.Sync.relocate(component.Path)
+		componentConfig.Profiles = nil
+		for _, profile := range source.Profiles {
+			componentConfig.Profiles = append(componentConfig.Profiles, &Profile{Name: profile.Name, Sync: profile.Sync.relocate(component.Path)})
+		}
+		if source.Changelog != nil {
+			componentConfig.Changelog = &Changelog{File: path.Join(component.Path, firstNonEmpty(source.Changelog.File, DefaultChangelogFile))}
+		}
+		componentConfig.parent, componentConfig.component = source, component
+		return &componentConfig, nil
+	}
+
+	// Inline component shares git and release settings of the project. Its version is stored in the project config.
+	componentConfig := *config
+	componentConfig.Version, componentConfig.Sync, componentConfig.Components = component.Version, component.Sync, nil
+	componentConfig.Profiles, componentConfig.Changelog = nil, nil
+	// Tag template of the project would give the same tags to all the components.
+	if component.Tag != nil {
+		componentConfig.Tag = component.Tag
+	} else if config.Tag != nil {
+		tag := *config.Tag
+		tag.Template = ""
+		componentConfig.Tag = &tag
+	}
+	componentConfig.parent, componentConfig.component = config, component
+	err := componentConfig.parseVersion()
+	if err != nil {
+		return nil, fmt.Errorf("invalid version of component %s: %w", name, err)
+	}
+	return &componentConfig, nil
+}
+
+// Returns components depending on the given one directly or transitively, with their patch segment bumped.
+func (config *VrsConfig) dependentBumps(fileSystem FileSystem, basedir string, name string) ([]*dependentBump, error) {
+	var bumps []*dependentBump
+	bumped := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		dependency := queue[0]
+		queue = queue[1:]
+		for _, component := range config.Components {
+			if bumped[component.Name] || !containsName(component.DependsOn, dependency) {
+				continue
+			}
+			bumped[component.Name] = true
+			queue = append(queue, component.Name)
+
+			dependentConfig, err := config.selectComponent(fileSystem, basedir, component.Name)
+			if err != nil {
+				return nil, err
+			}
+			scheme, err := dependentConfig.scheme(nil)
+			if err != nil {
+				return nil, err
+			}
+			bump := &dependentBump{config: dependentConfig, oldVersion: dependentConfig.Version}
+			dependentConfig.Version, err = scheme.Next(bump.oldVersion, PatchSegment)
+			if err != nil {
+				return nil, fmt.Errorf("cannot bump component %s: %w", component.Name, err)
+			}
+			bumps = append(bumps, bump)
+		}
+	}
+	return bumps, nil
+}
+
+// Writes new version of the dependent component and synchronizes its files.
+func (bump *dependentBump) release(executor *executor, activeProfiles []string) error {
+	bump.result = &BumpResult{OldVersion: bump.oldVersion, NewVersion: bump.config.Version}
+	activeProfiles = bump.config.activeProfiles(executor, activeProfiles)
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would change version of component %s %s to %s.\n", bump.config.component.Name, bump.oldVersion, bump.config.Version)
+		if err != nil {
+			return err
+		}
+	}
+	err := bump.config.write(executor)
+	if err != nil {
+		return err
+	}
+	oldVersion := bump.config.syncVersion(bump.oldVersion)
+	newVersion, err := bump.config.withBuildNumber(bump.config.syncVersion(bump.config.Version))
+	if err != nil {
+		return err
+	}
+	executor.buildNumber = bump.config.BuildNumber
+	for _, sync := range bump.config.activeSyncs(activeProfiles) {
+		err = syncFiles(executor, sync, oldVersion, newVersion, bump.result)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns sync settings with file names relative to the parent directory of the given one.
+func (sync *Sync) relocate(dir string) *Sync {
+	if sync == nil {
+		return nil
+	}
+	relocated := &Sync{Files: make([]SyncFile, len(sync.Files))}
+	for i, file := range sync.Files {
+		if file.Name != "" {
+			file.Name = path.Join(dir, file.Name)
+		}
+		if file.Directory != "" {
+			file.Directory = path.Join(dir, file.Directory)
+		}
+		relocated.Files[i] = file
+	}
+	for _, exclude := range sync.Exclude {
+		relocated.Exclude = append(relocated.Exclude, path.Join(dir, exclude))
+	}
+	return relocated
+}
+
+func containsName(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package vrs_test
+
+import (
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBumpInlineComponent(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("components:\n- name: api\n  version: 2.0.0\n  sync:\n    files:\n    - name: api/VERSION\n" +
+			"- name: web\n  version: 1.0.0\n"),
+		"api/VERSION": []byte("2.0.0"),
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "api", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2.1.0", result.NewVersion)
+	assert.Equal(t, "2.1.0", readInMemory(t, fileSystem, "api/VERSION"))
+	assert.Contains(t, readInMemory(t, fileSystem, "vrs.yml"), "components:\n- name: api\n  version: 2.1.0\n")
+	assert.NotContains(t, readInMemory(t, fileSystem, "vrs.yml"), "version: \"\"")
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: ".", Component: "web", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+	version, err = vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: ".", Component: "api", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.1.0", version)
+}
+
+func TestBumpComponentWithOwnConfig(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":              []byte("version: 1.0.0\ncomponents:\n- name: api\n  path: services/api\n"),
+		"services/api/vrs.yml": []byte("version: 3.0.0\nsync:\n  files:\n  - name: VERSION\n"),
+		"services/api/VERSION": []byte("3.0.0"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "api", Segment: vrs.MajorSegment, FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "4.0.0", readInMemory(t, fileSystem, "services/api/VERSION"))
+	assert.Equal(t, "version: 1.0.0\ncomponents:\n- name: api\n  path: services/api\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestFailOnUnknownComponent(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("components:\n- name: api\n  version: 1.0.0\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "web", FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, `unknown component "web"`)
+}
+
+func TestFailToBumpProjectWithoutVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("components:\n- name: api\n  version: 1.0.0\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, "project has no version of its own: select one of its components")
+}
+
+func TestTagComponentInItsNamespace(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("tag:\n  template: release-{{.Version}}\ncomponents:\n- name: api\n  version: 2.0.0\n" +
+			"- name: web\n  version: 1.0.0\n  tag:\n    template: '{{.Component}}-{{.Version}}'\n"),
+	})
+	git := &recordingGitClient{}
+
+	// When
+	api, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "api", GitCommit: true, FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+	assert.NoError(t, err)
+	web, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "web", GitCommit: true, FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "api/v2.1.0", api.Tag)
+	assert.Equal(t, "web-1.1.0", web.Tag)
+}
+
+func TestCascadeBumpToDependentComponents(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("components:\n- name: lib\n  version: 1.0.0\n" +
+			"- name: api\n  version: 2.0.0\n  dependsOn: [lib]\n  sync:\n    files:\n    - name: api/VERSION\n" +
+			"- name: web\n  version: 3.0.0\n  dependsOn: [api]\n" +
+			"- name: docs\n  version: 4.0.0\n"),
+		"api/VERSION": []byte("2.0.0"),
+	})
+	git := &recordingGitClient{}
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "lib", GitCommit: true, FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+	assert.Len(t, result.Dependents, 2)
+	assert.Equal(t, "2.0.1", result.Dependents[0].NewVersion)
+	assert.Equal(t, "web/v3.0.1", result.Dependents[1].Tag)
+	assert.Equal(t, "2.0.1", readInMemory(t, fileSystem, "api/VERSION"))
+	assert.Equal(t, []string{"add", "commit Version bump.", "tag lib/v1.1.0", "tag api/v2.0.1", "tag web/v3.0.1"}, git.calls)
+	for component, version := range map[string]string{"api": "2.0.1", "web": "3.0.1", "docs": "4.0.0"} {
+		current, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: ".", Component: component, FileSystem: fileSystem})
+		assert.NoError(t, err)
+		assert.Equal(t, version, current)
+	}
+}
+
+func TestFailToLoadConfigWithInvalidComponentVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("components:\n- name: api\n  version: 1.0.x\n" +
+		"- name: web\n  version: 1.0.0\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "web", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+	assert.Contains(t, err.Error(), `invalid version of component api: invalid version "1.0.x"`)
+}
@@ -0,0 +1,105 @@
+package vrs
+
+import (
+	"bytes"
+	"errors"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+	"sort"
+)
+
+// Returns content of the config file with versions of the config and its inline components updated. If the file
+// differs from the config by the versions only, just the version scalars are rewritten, so comments, key order and
+// layout of the file are preserved. Otherwise the whole config is marshalled.
+func (config *VrsConfig) fileContent(original []byte) ([]byte, error) {
+	yml, err := yamlv2.Marshal(config.declaredConfig())
+	if err != nil {
+		return nil, err
+	}
+	if original != nil {
+		updated, err := config.updateVersions(original)
+		if err == nil && sameConfig(updated, yml) {
+			return updated, nil
+		}
+	}
+	return preserveLineEndings(original, yml), nil
+}
+
+// Returns whether the config file contains the same settings as the marshalled config.
+func sameConfig(content []byte, yml []byte) bool {
+	config := &VrsConfig{}
+	err := yamlv2.Unmarshal(content, config)
+	if err != nil {
+		return false
+	}
+	marshalled, err := yamlv2.Marshal(config)
+	return err == nil && bytes.Equal(marshalled, yml)
+}
+
+var errVersionNotDeclared = errors.New("version is not declared in the config file")
+
+func (config *VrsConfig) updateVersions(original []byte) ([]byte, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(original, document)
+	if err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, errVersionNotDeclared
+	}
+	root := document.Content[0]
+
+	versions := map[*yaml.Node]string{}
+	if config.Version != "" {
+		versions[mappingValue(root, "version")] = config.prefixedVersion(config.Version)
+	}
+	for _, component := range config.Components {
+		if component.Path == "" {
+			versions[componentNode(root, component.Name, "version")] = config.prefixedVersion(component.Version)
+		}
+	}
+	lineStarts := []int{0}
+	for i, character := range original {
+		if character == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	type replacement struct {
+		span  [2]int
+		value []byte
+	}
+	var replacements []replacement
+	for node, version := range versions {
+		if node == nil || node.Kind != yaml.ScalarNode {
+			return nil, errVersionNotDeclared
+		}
+		span, err := yamlScalarSpan(original, lineStarts, node)
+		if err != nil {
+			return nil, err
+		}
+		replacements = append(replacements, replacement{span: span, value: []byte(formatYAMLScalar(node.Style, version))})
+	}
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].span[0] < replacements[j].span[0]
+	})
+	spans, values := make([][2]int, len(replacements)), make([][]byte, len(replacements))
+	for i, replacement := range replacements {
+		spans[i], values[i] = replacement.span, replacement.value
+	}
+	return replaceSpans(original, spans, values), nil
+}
+
+// Returns value of the given key of the component declared in the config document.
+func componentNode(root *yaml.Node, name string, key string) *yaml.Node {
+	components := mappingValue(root, "components")
+	if components == nil || components.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, component := range components.Content {
+		componentName := mappingValue(component, "name")
+		if componentName != nil && componentName.Value == name {
+			return mappingValue(component, key)
+		}
+	}
+	return nil
+}
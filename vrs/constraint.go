@@ -0,0 +1,253 @@
+package vrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Range of versions, for example ">=1.2, <2.0" or "~1.4.x". Constraint consists of alternatives separated by ||, each
+// of them consisting of comma-separated conditions which have to be satisfied all.
+//
+// Conditions compare versions using the =, !=, >, >=, < and <= operators. Partial versions match all the versions
+// starting with the given segments, so <=1.4 matches 1.4.9 and =1.4 (or just 1.4, 1.4.x or 1.4.*) matches 1.4.0 up to,
+// but excluding, 1.5.0. Tilde allows changes of the patch segment (~1.4.2 means >=1.4.2, <1.5.0) and caret allows
+// changes which don't modify the first non-zero segment (^1.4.2 means >=1.4.2, <2.0.0). Prereleases are ordered
+// according to semantic versioning precedence rules, except that prereleases of the upper bound are excluded from the
+// range. Versions with prerelease or build metadata, like =1.4.2-rc.1, are compared exactly.
+type Constraint struct {
+	expression   string
+	alternatives [][]versionCondition
+}
+
+type versionCondition func(version string) (bool, error)
+
+var constraintOperators = []string{"!=", ">=", "<=", "==", "=", ">", "<", "~", "^"}
+
+func ParseConstraint(expression string) (*Constraint, error) {
+	constraint := &Constraint{expression: expression}
+	for _, alternative := range strings.Split(expression, "||") {
+		var conditions []versionCondition
+		for _, term := range strings.Split(alternative, ",") {
+			condition, err := parseCondition(strings.TrimSpace(term))
+			if err != nil {
+				return nil, fmt.Errorf("%w %q: %s", ErrInvalidConstraint, expression, err)
+			}
+			conditions = append(conditions, condition)
+		}
+		constraint.alternatives = append(constraint.alternatives, conditions)
+	}
+	return constraint, nil
+}
+
+// Returns whether the version satisfies the constraint.
+func (constraint *Constraint) Check(version string) (bool, error) {
+	for _, conditions := range constraint.alternatives {
+		satisfied := true
+		for _, condition := range conditions {
+			ok, err := condition(version)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (constraint *Constraint) String() string {
+	return constraint.expression
+}
+
+// Returns whether the version satisfies the constraint, for example whether 1.4.2 satisfies ">=1.2, <2.0".
+func Satisfies(version string, constraint string) (bool, error) {
+	parsedConstraint, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	return parsedConstraint.Check(version)
+}
+
+func parseCondition(term string) (versionCondition, error) {
+	if term == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+	operator := ""
+	for _, candidate := range constraintOperators {
+		if strings.HasPrefix(term, candidate) {
+			operator = candidate
+			break
+		}
+	}
+	lower, upper, segments, err := parseConstraintVersion(strings.TrimSpace(term[len(operator):]))
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(lower, "-+") && operator != "~" && operator != "^" {
+		return compareCondition(operator, lower), nil
+	}
+
+	switch operator {
+	case "~":
+		if len(segments) > 2 {
+			upper = nextConstraintVersion(segments[:2])
+		}
+	case "^":
+		significant := len(segments)
+		for i, segment := range segments {
+			if segment != 0 {
+				significant = i + 1
+				break
+			}
+		}
+		upper = nextConstraintVersion(segments[:significant])
+	}
+	inRange := func(version string) (bool, error) {
+		return versionInRange(version, lower, upper)
+	}
+	switch operator {
+	case "", "=", "==", "~", "^":
+		return inRange, nil
+	case "!=":
+		return func(version string) (bool, error) {
+			ok, err := inRange(version)
+			return !ok, err
+		}, nil
+	case ">":
+		return func(version string) (bool, error) {
+			if upper == "" {
+				return false, nil
+			}
+			ok, err := versionBelow(version, upper)
+			return !ok, err
+		}, nil
+	case ">=":
+		return func(version string) (bool, error) {
+			return versionInRange(version, lower, "")
+		}, nil
+	case "<":
+		return func(version string) (bool, error) {
+			return versionBelow(version, lower)
+		}, nil
+	default:
+		return func(version string) (bool, error) {
+			return versionInRange(version, "", upper)
+		}, nil
+	}
+}
+
+// Returns condition comparing versions with the full version, which represents single version rather than a range.
+func compareCondition(operator string, bound string) versionCondition {
+	return func(version string) (bool, error) {
+		result, err := Compare(version, bound)
+		if err != nil {
+			return false, err
+		}
+		switch operator {
+		case "!=":
+			return result != 0, nil
+		case ">":
+			return result > 0, nil
+		case ">=":
+			return result >= 0, nil
+		case "<":
+			return result < 0, nil
+		case "<=":
+			return result <= 0, nil
+		default:
+			return result == 0, nil
+		}
+	}
+}
+
+// Parses version of the condition, which might be partial or contain wildcards (1.4, 1.4.x, 1.*). Returns the lowest
+// version matching it, the lowest version above it (empty if there is no such version) and its numeric segments.
+func parseConstraintVersion(version string) (string, string, []int, error) {
+	_, version = splitVersionPrefix(version)
+	if version == "" {
+		return "", "", nil, fmt.Errorf("missing version")
+	}
+	if strings.ContainsAny(version, "-+") {
+		parsed, err := ParseVersion(version)
+		if err != nil {
+			return "", "", nil, err
+		}
+		segments := []int{parsed.Major, parsed.Minor, parsed.Patch}
+		return version, nextConstraintVersion(segments), segments, nil
+	}
+
+	var segments []int
+	for _, part := range strings.Split(version, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		number, err := parseVersionNumber(part)
+		if err != nil {
+			return "", "", nil, err
+		}
+		segments = append(segments, number)
+	}
+	lower := make([]int, len(segments))
+	copy(lower, segments)
+	for len(lower) < 3 {
+		lower = append(lower, 0)
+	}
+	return formatNumericVersion(lower), nextConstraintVersion(segments), segments, nil
+}
+
+// Returns the lowest release above all the versions starting with given segments. Empty version is returned if there
+// are no segments, as all the versions start with them.
+func nextConstraintVersion(segments []int) string {
+	if len(segments) == 0 {
+		return ""
+	}
+	next := make([]int, len(segments))
+	copy(next, segments)
+	next[len(next)-1]++
+	for len(next) < 3 {
+		next = append(next, 0)
+	}
+	return formatNumericVersion(next)
+}
+
+// Returns whether the version is at least the lower version and below the upper one. Empty bounds are ignored.
+func versionInRange(version string, lower string, upper string) (bool, error) {
+	if lower != "" {
+		ok, err := compareAtLeast(version, lower)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if upper != "" {
+		return versionBelow(version, upper)
+	}
+	return true, nil
+}
+
+// Returns whether the version is below the bound. Prereleases of the bound are not below it, unless the bound is
+// a prerelease itself, so <2.0.0 doesn't match 2.0.0-rc.1.
+func versionBelow(version string, bound string) (bool, error) {
+	if !strings.Contains(bound, "-") {
+		version = versionCore(version)
+	}
+	ok, err := compareAtLeast(version, bound)
+	return !ok, err
+}
+
+// Returns the version without prerelease and build metadata.
+func versionCore(version string) string {
+	if index := strings.IndexAny(version, "-+"); index >= 0 {
+		return version[:index]
+	}
+	return version
+}
+
+func compareAtLeast(version string, bound string) (bool, error) {
+	result, err := Compare(version, bound)
+	return result >= 0, err
+}
@@ -0,0 +1,56 @@
+package vrs_test
+
+import (
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSatisfiesConstraint(t *testing.T) {
+	for _, check := range []struct {
+		version, constraint string
+		expected            bool
+	}{
+		{"1.4.2", ">=1.2, <2.0", true},
+		{"2.0.0", ">=1.2, <2.0", false},
+		{"2.0.0-rc.1", ">=1.2, <2.0", false},
+		{"1.4.9", "~1.4.x", true},
+		{"1.5.0", "~1.4.x", false},
+		{"1.4.1", "~1.4.2", false},
+		{"1.9.0", "^1.4.2", true},
+		{"0.5.0", "^0.4.2", false},
+		{"v1.4.0", "1.4", true},
+		{"1.4.9", "<=1.4", true},
+		{"1.5.0", ">1.4", true},
+		{"1.4.9", ">1.4", false},
+		{"1.3.0", "!=1.4.*", true},
+		{"3.1.0", "<2 || >=3", true},
+		{"2.5.0", "*", true},
+		{"1.4.2-rc.1", "=1.4.2-rc.1", true},
+		{"1.4.2", "=1.4.2-rc.1", false},
+		{"1.4.2-rc.5", "=1.4.2-rc.1", false},
+		{"1.4.2", "<=1.4.2-rc.1", false},
+		{"1.4.2-rc.1", "<=1.4.2-rc.1", true},
+		{"1.4.2-rc.2", ">1.4.2-rc.1", true},
+		{"1.4.2", ">1.4.2-rc.1", true},
+		{"1.4.2-rc.1", ">1.4.2-rc.1", false},
+		{"1.4.2", "!=1.4.2-rc.1", true},
+		{"1.4.9", "~1.4.2-rc.1", true},
+	} {
+		// When
+		satisfied, err := vrs.Satisfies(check.version, check.constraint)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, check.expected, satisfied, check.version+" "+check.constraint)
+	}
+}
+
+func TestParseInvalidConstraint(t *testing.T) {
+	// When
+	_, err := vrs.ParseConstraint(">=1.2, <two")
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidConstraint))
+}
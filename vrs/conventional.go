@@ -0,0 +1,66 @@
+package vrs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Segment selected automatically from the commits made since the last release tag, see ConventionalSegment.
+const AutoSegment = "auto"
+
+// Commit message following Conventional Commits specification (https://www.conventionalcommits.org), for example
+// "feat(parser)!: drop support of legacy format".
+type ConventionalCommit struct {
+	Type  string
+	Scope string
+	// Commit marked with ! after the type or containing BREAKING CHANGE footer.
+	Breaking    bool
+	Description string
+}
+
+var conventionalCommitSubject = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?: *(.+)$`)
+
+var conventionalCommitBreakingFooter = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+
+// Parses commit message split into subject and body. False is returned if the subject doesn't follow Conventional
+// Commits.
+func ParseConventionalCommit(subject string, body string) (*ConventionalCommit, bool) {
+	match := conventionalCommitSubject.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return nil, false
+	}
+	return &ConventionalCommit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    match[3] != "" || conventionalCommitBreakingFooter.MatchString(body),
+		Description: match[4],
+	}, true
+}
+
+// Returns version segment which should be bumped to release given commits. Breaking changes bump major segment,
+// features (feat type) bump minor segment and all other commits bump patch segment.
+func ConventionalSegment(commits []*GitLogEntry) BumpKind {
+	segment := BumpKind(PatchSegment)
+	for _, entry := range commits {
+		commit, ok := ParseConventionalCommit(entry.Subject, entry.Body)
+		if !ok {
+			continue
+		}
+		if commit.Breaking {
+			return MajorSegment
+		}
+		if commit.Type == "feat" {
+			segment = MinorSegment
+		}
+	}
+	return segment
+}
+
+// Chooses bumped segment from the commits made since the last release tag.
+func (config *VrsConfig) autoSegment(executor *executor, gitOptions *GitOptions) (BumpKind, error) {
+	commits, err := config.unreleasedCommits(executor, gitOptions)
+	if err != nil {
+		return "", err
+	}
+	return ConventionalSegment(commits), nil
+}
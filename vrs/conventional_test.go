@@ -0,0 +1,35 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	// When
+	commit, ok := vrs.ParseConventionalCommit("feat(parser)!: drop legacy format", "")
+
+	// Then
+	assert.True(t, ok)
+	assert.Equal(t, &vrs.ConventionalCommit{Type: "feat", Scope: "parser", Breaking: true, Description: "drop legacy format"}, commit)
+}
+
+func TestParseNonConventionalCommit(t *testing.T) {
+	// When
+	_, ok := vrs.ParseConventionalCommit("Fixed parser.", "")
+
+	// Then
+	assert.False(t, ok)
+}
+
+func TestConventionalSegment(t *testing.T) {
+	// Given
+	commits := []*vrs.GitLogEntry{{Subject: "fix: typo"}, {Subject: "Merge branch 'main'"}, {Subject: "feat: new option"}}
+
+	// When
+	segment := vrs.ConventionalSegment(commits)
+
+	// Then
+	assert.Equal(t, vrs.BumpKind(vrs.MinorSegment), segment)
+}
@@ -0,0 +1,96 @@
+package vrs
+
+import (
+	"context"
+	"fmt"
+)
+
+const DefaultDevIdentifier = "dev"
+
+// Length of abbreviated commit SHA, the default of git describe.
+const shortSHALength = 7
+
+type DevVersionOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project. Empty name selects the project itself.
+	Component string
+	// Segment bumped to compute the version under development. Minor segment is bumped by default.
+	Segment string
+	// Prerelease identifier of the development version. Defaults to DefaultDevIdentifier.
+	Identifier string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Client reading the release tags and commits. Defaults to GoGitClient reading the repository of the
+	// project directory.
+	GitClient GitClient
+}
+
+func NewDefaultDevVersionOptions() (*DevVersionOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &DevVersionOptions{Basedir: basedir, UserConfigFile: defaultUserConfigFile()}, nil
+}
+
+// Returns version of snapshot build made between releases, in the style of git describe. The version consists of the
+// next version, the number of commits since the last release tag and the abbreviated SHA of HEAD, for example
+// 1.4.0-dev.5+gabc1234. The current version is returned if HEAD is the release commit itself.
+func DevVersion(options *DevVersionOptions) (string, error) {
+	if options == nil {
+		o, err := NewDefaultDevVersionOptions()
+		if err != nil {
+			return "", err
+		}
+		options = o
+	}
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
+	if err != nil {
+		return "", err
+	}
+	executor := newExecutor(context.Background(), options.Basedir, false, nil, options.GitClient, options.FileSystem, nil)
+	err = config.readTagVersion(executor, nil)
+	if err != nil {
+		return "", err
+	}
+
+	pattern, err := config.tagPattern(executor, nil)
+	if err != nil {
+		return "", err
+	}
+	lastTag, err := executor.git.LastTag(executor.ctx, "", pattern)
+	if err != nil {
+		return "", fmt.Errorf("cannot read last release tag: %w", err)
+	}
+	commits, err := executor.git.Log(executor.ctx, lastTag, "")
+	if err != nil {
+		return "", fmt.Errorf("cannot read commits since last release: %w", err)
+	}
+	if lastTag != "" && len(commits) == 0 {
+		return config.Version, nil
+	}
+	head, err := executor.git.Head(executor.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scheme, err := config.scheme(nil)
+	if err != nil {
+		return "", err
+	}
+	next, err := scheme.Next(config.Version, BumpKind(options.Segment))
+	if err != nil {
+		return "", err
+	}
+	version := fmt.Sprintf("%s-%s.%d", stripMetadata(next), firstNonEmpty(options.Identifier, DefaultDevIdentifier), len(commits))
+	if head != "" {
+		version += "+g" + shortSHA(head)
+	}
+	return version, nil
+}
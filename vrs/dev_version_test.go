@@ -0,0 +1,36 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDevVersion(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.3.0"})
+	for i := 0; i < 2; i++ {
+		gitOutput(t, basedir, "commit", "--allow-empty", "-m", "Change.")
+	}
+	shortSHA := strings.TrimSpace(gitOutput(t, basedir, "rev-parse", "--short=7", "HEAD"))
+
+	// When
+	version, err := vrs.DevVersion(&vrs.DevVersionOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0-dev.2+g"+shortSHA, version)
+}
+
+func TestDevVersionOfReleaseCommit(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.3.0"})
+
+	// When
+	version, err := vrs.DevVersion(&vrs.DevVersionOptions{Basedir: basedir, Segment: vrs.PatchSegment})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", version)
+}
@@ -0,0 +1,58 @@
+package vrs
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Returns the closest directory containing one of ConfigFileNames, starting from the given one and walking up its
+// parents like git does. Search stops at the root of the git repository. Returns NoVersioonFileFound if no config file
+// has been found.
+func FindProjectDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range ConfigFileNames {
+			if fileExists(filepath.Join(dir, name)) {
+				return dir, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if fileExists(filepath.Join(dir, ".git")) || parent == dir {
+			return "", NoVersioonFileFound
+		}
+		dir = parent
+	}
+}
+
+// Returns directory of the project enclosing the working directory, or the working directory itself if there is none.
+func defaultBasedir() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	dir, err := FindProjectDir(wd)
+	if err != nil {
+		return wd, nil
+	}
+	return dir, nil
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+// Returns path of the config file relative to the project directory.
+func relativeConfigPath(basedir string, configPath string) (string, error) {
+	if !filepath.IsAbs(configPath) {
+		return configPath, nil
+	}
+	basedir, err := filepath.Abs(basedir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(basedir, configPath)
+}
@@ -0,0 +1,41 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectDirInParentDirectory(t *testing.T) {
+	// Given
+	project := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(project, ".git"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(project, "vrs.yml"), []byte("version: 1.0.0\n"), 0644))
+	subdir := filepath.Join(project, "cmd", "app")
+	assert.NoError(t, os.MkdirAll(subdir, 0755))
+
+	// When
+	dir, err := vrs.FindProjectDir(subdir)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, project, dir)
+}
+
+func TestStopProjectDirSearchAtGitRoot(t *testing.T) {
+	// Given
+	outer := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(outer, "vrs.yml"), []byte("version: 1.0.0\n"), 0644))
+	subdir := filepath.Join(outer, "repo", "docs")
+	assert.NoError(t, os.MkdirAll(subdir, 0755))
+	assert.NoError(t, os.Mkdir(filepath.Join(outer, "repo", ".git"), 0755))
+
+	// When
+	_, err := vrs.FindProjectDir(subdir)
+
+	// Then
+	assert.Equal(t, vrs.NoVersioonFileFound, err)
+}
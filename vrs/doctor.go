@@ -0,0 +1,158 @@
+package vrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+type DoctorOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Remote repository releases are pushed to. Defaults to the remote from the config or origin.
+	Remote string
+	// Skips verification of push access, which connects to the remote repository.
+	Offline bool
+}
+
+func NewDefaultDoctorOptions() (*DoctorOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &DoctorOptions{Basedir: basedir, UserConfigFile: defaultUserConfigFile()}, nil
+}
+
+// Result of a single diagnostic of Doctor.
+type DoctorFinding struct {
+	// Name of the diagnostic, for example git or remote.
+	Check string
+	// What has been found, for example git version or the problem.
+	Message string
+	Failed  bool
+	// Suggested fix of the problem. Empty if the check passed.
+	Advice string
+}
+
+// Diagnoses the environment of the project before a release is attempted: git installation, state of the repository,
+// remote repository and push access to it, validity of the config and existence of the synchronized files.
+func Doctor(options *DoctorOptions) ([]*DoctorFinding, error) {
+	if options == nil {
+		o, err := NewDefaultDoctorOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	ctx := context.Background()
+	var findings []*DoctorFinding
+	passed := func(check string, message string) {
+		findings = append(findings, &DoctorFinding{Check: check, Message: message})
+	}
+	failed := func(check string, message string, advice string) {
+		findings = append(findings, &DoctorFinding{Check: check, Message: message, Failed: true, Advice: advice})
+	}
+
+	git := NewExecGitClient(options.Basedir)
+	version, err := git.run(ctx, "--version")
+	if err != nil {
+		if errors.Is(err, ErrGitNotInstalled) {
+			failed("git", "git executable not found", "install git and make sure it is in PATH")
+			return findings, nil
+		}
+		return nil, err
+	}
+	passed("git", strings.TrimSpace(version))
+
+	repositoryReady := false
+	changes, err := git.Status(ctx)
+	switch {
+	case err != nil:
+		failed("repository", "project is not in git repository", "initialize the repository with git init")
+	case len(changes) > 0:
+		failed("repository", fmt.Sprintf("working tree has %d uncommitted changes", len(changes)),
+			"commit or stash the changes, or release with --allow-dirty")
+	default:
+		repositoryReady = true
+		passed("repository", "working tree is clean")
+	}
+
+	config, configErr := parseConfigFile(nil, options.Basedir, options.ConfigPath, options.UserConfigFile)
+	problems, err := ValidateConfig(&ValidateConfigOptions{Basedir: options.Basedir, ConfigPath: options.ConfigPath})
+	switch {
+	case errors.Is(err, NoVersioonFileFound):
+		failed("config", "no config file found", "create the config with vrs init")
+	case err != nil:
+		failed("config", err.Error(), "fix the config file")
+	case len(problems) > 0:
+		failed("config", fmt.Sprintf("config has %d problems, the first one is %s", len(problems), problems[0]),
+			"list the problems with vrs validate-config")
+	case configErr != nil:
+		failed("config", configErr.Error(), "fix the config file")
+	default:
+		passed("config", "config is valid")
+	}
+
+	if repositoryReady {
+		remote := options.Remote
+		if remote == "" && config != nil {
+			remote, _ = config.pushTarget(nil)
+		}
+		remote = firstNonEmpty(remote, "origin")
+		url, err := git.run(ctx, "remote", "get-url", remote)
+		if err != nil {
+			failed("remote", fmt.Sprintf("remote repository %s is not configured", remote),
+				fmt.Sprintf("add the remote with git remote add %s URL", remote))
+		} else {
+			passed("remote", fmt.Sprintf("%s is %s", remote, strings.TrimSpace(url)))
+			if !options.Offline {
+				findings = append(findings, checkPushAccess(ctx, options.Basedir, remote))
+			}
+		}
+	}
+
+	if configErr == nil {
+		files, err := Check(&CheckOptions{Basedir: options.Basedir, ConfigPath: options.ConfigPath, UserConfigFile: options.UserConfigFile})
+		if err != nil {
+			failed("sync", err.Error(), "fix sync files in the config")
+			return findings, nil
+		}
+		missing := 0
+		for _, file := range files {
+			if file.Problem == "file is missing" {
+				missing++
+				failed("sync", fmt.Sprintf("sync file %s doesn't exist", file.Name), "create the file or remove it from the config")
+			}
+		}
+		if missing == 0 {
+			passed("sync", fmt.Sprintf("all %d sync files exist", len(files)))
+		}
+	}
+	return findings, nil
+}
+
+// Verifies push access to the remote repository by dry run of the push, failing instead of prompting for credentials.
+func checkPushAccess(ctx context.Context, basedir string, remote string) *DoctorFinding {
+	// #nosec - Git arguments are controlled by vrs.
+	cmd := exec.CommandContext(ctx, "git", "push", "--dry-run", "--porcelain", remote, "HEAD")
+	cmd.Dir = basedir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(output))
+		if lines := strings.Split(message, "\n"); len(lines) > 0 {
+			message = lines[0]
+		}
+		return &DoctorFinding{Check: "push", Message: fmt.Sprintf("cannot push to %s: %s", remote, message), Failed: true,
+			Advice: "configure credentials of the remote repository, for example with a credential helper or SSH key"}
+	}
+	return &DoctorFinding{Check: "push", Message: fmt.Sprintf("push to %s is allowed", remote)}
+}
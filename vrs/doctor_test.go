@@ -0,0 +1,42 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDoctorHealthyProject(t *testing.T) {
+	// Given
+	basedir := newPushedGitProject(t, nil)
+	gitOutput(t, basedir, "add", "vrs.yml")
+	gitOutput(t, basedir, "commit", "-m", "Configure push.")
+
+	// When
+	findings, err := vrs.Doctor(&vrs.DoctorOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	var checks []string
+	for _, finding := range findings {
+		assert.False(t, finding.Failed, finding.Message)
+		checks = append(checks, finding.Check)
+	}
+	assert.Equal(t, []string{"git", "repository", "config", "remote", "push", "sync"}, checks)
+}
+
+func TestDoctorProjectOutsideOfRepository(t *testing.T) {
+	// Given
+	basedir := t.TempDir()
+	assert.NoError(t, (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}).Write(basedir))
+
+	// When
+	findings, err := vrs.Doctor(&vrs.DoctorOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, findings, 4)
+	assert.Equal(t, "project is not in git repository", findings[1].Message)
+	assert.True(t, findings[1].Failed)
+	assert.Equal(t, "sync file VERSION doesn't exist", findings[3].Message)
+}
@@ -0,0 +1,60 @@
+package vrs
+
+import (
+	"gopkg.in/yaml.v2"
+	"os"
+	"regexp"
+)
+
+// Reference to environment variable in config values, for example ${CI_REGISTRY} or ${REMOTE:-origin} with the value
+// used if the variable is not set or is empty.
+var environmentReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// Parses config replacing references to environment variables in its values. If any variable has been referenced,
+// the config as declared in the file is kept to be written back.
+func parseConfig(yml []byte) (*VrsConfig, error) {
+	config := &VrsConfig{}
+	err := yaml.Unmarshal(yml, config)
+	if err != nil {
+		return nil, err
+	}
+	if !environmentReference.Match(yml) {
+		return config, nil
+	}
+
+	var values interface{}
+	err = yaml.Unmarshal(yml, &values)
+	if err != nil {
+		return nil, err
+	}
+	expandedYml, err := yaml.Marshal(expandEnvironment(values))
+	if err != nil {
+		return nil, err
+	}
+	expanded := &VrsConfig{}
+	err = yaml.Unmarshal(expandedYml, expanded)
+	if err != nil {
+		return nil, err
+	}
+	expanded.declared = config
+	return expanded, nil
+}
+
+func expandEnvironment(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return environmentReference.ReplaceAllStringFunc(value, func(reference string) string {
+			groups := environmentReference.FindStringSubmatch(reference)
+			return firstNonEmpty(os.Getenv(groups[1]), groups[2])
+		})
+	case map[interface{}]interface{}:
+		for key, item := range value {
+			value[key] = expandEnvironment(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = expandEnvironment(item)
+		}
+	}
+	return value
+}
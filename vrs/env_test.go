@@ -0,0 +1,28 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExpandEnvironmentVariablesInConfig(t *testing.T) {
+	// Given
+	setenv(t, "DIST_DIR", "dist")
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: ${DIST_DIR}/VERSION\n  - name: ${DOCS_DIR:-docs}/VERSION\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":      []byte(config),
+		"dist/VERSION": []byte("1.0.0"),
+		"docs/VERSION": []byte("1.0.0"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "dist/VERSION"))
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "docs/VERSION"))
+	assert.Contains(t, readInMemory(t, fileSystem, "vrs.yml"), "name: ${DIST_DIR}/VERSION")
+	assert.Contains(t, readInMemory(t, fileSystem, "vrs.yml"), "version: 1.1.0")
+}
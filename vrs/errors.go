@@ -0,0 +1,81 @@
+package vrs
+
+import (
+	"errors"
+	"strings"
+)
+
+// Version doesn't conform to the versioning scheme of the project.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// Version constraint can't be parsed.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// File configured for version synchronization doesn't exist.
+var ErrSyncFileMissing = errors.New("sync file not found")
+
+// Required sync file contains no occurrence of the version or the pattern.
+var ErrSyncPatternNotMatched = errors.New("sync pattern matched nothing")
+
+// Sync file contains binary data, so replacing the version could corrupt it.
+var ErrBinarySyncFile = errors.New("sync file is binary")
+
+// Git executable is not available. ExecGitClient requires it, GoGitClient only to sign commits and tags.
+var ErrGitNotInstalled = errors.New("git executable not found")
+
+// Project directory is not inside of git repository, so release commit and tag cannot be created.
+var ErrNotGitRepository = errors.New("not a git repository")
+
+// Git command exited with an error. Returned errors are of type *GitCommandError.
+var ErrGitCommandFailed = errors.New("git command failed")
+
+// Failure of git command. Matches ErrGitCommandFailed when used with errors.Is.
+type GitCommandError struct {
+	Args []string
+	// Standard error output of the command. Failures of GoGitClient carry the message of go-git error instead.
+	Stderr string
+	// Underlying error, for example exit status of the command or the cancellation of its context.
+	Err error
+}
+
+func (err *GitCommandError) Error() string {
+	message := strings.TrimSpace(err.Stderr)
+	if message == "" {
+		message = err.Err.Error()
+	}
+	command := ""
+	if len(err.Args) > 0 {
+		command = err.Args[0] + " "
+	}
+	return "git " + command + "failed: " + message
+}
+
+func (err *GitCommandError) Unwrap() error {
+	return err.Err
+}
+
+func (err *GitCommandError) Is(target error) bool {
+	return target == ErrGitCommandFailed
+}
+
+// Failures of multiple synced files. Matches each of the errors when used with errors.Is.
+type SyncError struct {
+	Errors []error
+}
+
+func (err *SyncError) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, fileErr := range err.Errors {
+		messages[i] = fileErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (err *SyncError) Is(target error) bool {
+	for _, fileErr := range err.Errors {
+		if errors.Is(fileErr, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package vrs
+
+import "context"
+
+// Callbacks notified about the steps of operations changing the version, so programs embedding vrs can automate
+// additional tasks. Error returned by a callback vetoes the operation: it is aborted and its changes are rolled back.
+// In dry run mode callbacks are notified about the steps which would be executed.
+type Events interface {
+	// Called when the new version is computed, before any file is changed.
+	OnVersionComputed(ctx context.Context, oldVersion string, newVersion string) error
+	// Called after the file is synchronized with the new version.
+	OnFileSynced(ctx context.Context, file *SyncedFile) error
+	// Called after the release commit is created. SHA is empty in dry run mode.
+	OnCommit(ctx context.Context, sha string, message string) error
+	// Called after the release tag is created.
+	OnTag(ctx context.Context, tag string) error
+	// Called before changes are pushed to the remote repository, as pushed changes can't be rolled back. Empty remote
+	// and branch mean git defaults.
+	OnPush(ctx context.Context, remote string, branch string) error
+}
+
+// Events ignoring all the notifications. Embed it to implement only some of the callbacks.
+type NopEvents struct{}
+
+func (NopEvents) OnVersionComputed(ctx context.Context, oldVersion string, newVersion string) error {
+	return nil
+}
+
+func (NopEvents) OnFileSynced(ctx context.Context, file *SyncedFile) error {
+	return nil
+}
+
+func (NopEvents) OnCommit(ctx context.Context, sha string, message string) error {
+	return nil
+}
+
+func (NopEvents) OnTag(ctx context.Context, tag string) error {
+	return nil
+}
+
+func (NopEvents) OnPush(ctx context.Context, remote string, branch string) error {
+	return nil
+}
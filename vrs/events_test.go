@@ -0,0 +1,82 @@
+package vrs_test
+
+import (
+	"context"
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+type recordingEvents struct {
+	vrs.NopEvents
+	events  []string
+	pushErr error
+}
+
+func (events *recordingEvents) OnVersionComputed(ctx context.Context, oldVersion string, newVersion string) error {
+	events.events = append(events.events, "version "+oldVersion+" "+newVersion)
+	return nil
+}
+
+func (events *recordingEvents) OnFileSynced(ctx context.Context, file *vrs.SyncedFile) error {
+	events.events = append(events.events, "synced "+file.Name)
+	return nil
+}
+
+func (events *recordingEvents) OnCommit(ctx context.Context, sha string, message string) error {
+	events.events = append(events.events, "commit "+message)
+	return nil
+}
+
+func (events *recordingEvents) OnTag(ctx context.Context, tag string) error {
+	events.events = append(events.events, "tag "+tag)
+	return nil
+}
+
+func (events *recordingEvents) OnPush(ctx context.Context, remote string, branch string) error {
+	events.events = append(events.events, "push")
+	return events.pushErr
+}
+
+func TestNotifyEvents(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}).Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.0.0"), 0644)
+	assert.NoError(t, err)
+	events := &recordingEvents{}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, Events: events,
+		GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"version 1.0.0 1.1.0", "synced VERSION", "commit Version bump.", "tag v1.1.0", "push"}, events.events)
+}
+
+func TestVetoPush(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0"}).Write(basedir)
+	assert.NoError(t, err)
+	vetoErr := errors.New("release freeze")
+	git := &recordingGitClient{}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, Events: &recordingEvents{pushErr: vetoErr},
+		GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.True(t, errors.Is(err, vetoErr))
+	assert.Equal(t, []string{"add", "commit Version bump.", "tag v1.1.0", "delete tag v1.1.0", "reset", "unstage"}, git.calls)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
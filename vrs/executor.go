@@ -0,0 +1,222 @@
+package vrs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+)
+
+// Executes file and git operations on the project. In dry run mode operations are only reported to the output and
+// nothing is changed.
+//
+// Executed operations are recorded, so they can be rolled back if a subsequent step fails. Once changes are pushed to
+// the remote repository, they are considered published and are not rolled back anymore.
+type executor struct {
+	baseDir    string
+	dryRun     bool
+	output     io.Writer
+	git        GitClient
+	fileSystem FileSystem
+	events     Events
+	// Versions of the bump available to templates and hooks. Nil outside of bump.
+	bump *bumpVariables
+	// Permissions of created files. Existing files keep their permissions.
+	fileMode os.FileMode
+	// Build number settings of the synced project or component. Old versions of text syncs are matched with any build
+	// number. Nil if build number is not configured.
+	buildNumber *BuildNumber
+	// Context of the changing operations. Rollback is not bound to it, so cancelled operation still reverts its
+	// changes.
+	ctx context.Context
+	// Steps reverting executed operations, in order of execution.
+	rollbackSteps []func() error
+}
+
+func newExecutor(ctx context.Context, baseDir string, dryRun bool, output io.Writer, git GitClient, fileSystem FileSystem, events Events) *executor {
+	if output == nil {
+		output = os.Stdout
+	}
+	if git == nil {
+		git = NewGoGitClient(baseDir)
+	}
+	if dryRun {
+		git = &dryRunGitClient{GitClient: git, output: output}
+	}
+	if events == nil {
+		events = NopEvents{}
+	}
+	return &executor{baseDir: baseDir, dryRun: dryRun, output: output, git: git, fileSystem: fileSystemOrDefault(fileSystem), events: events, fileMode: DefaultFileMode, ctx: ctx}
+}
+
+func (executor *executor) writeFile(file string, content []byte) error {
+	if err := executor.ctx.Err(); err != nil {
+		return err
+	}
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would write file %s.\n", file)
+		return err
+	}
+
+	filePath := path.Join(executor.baseDir, file)
+	originalContent, err := executor.fileSystem.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	existed := err == nil
+	mode := executor.fileMode
+	if existed {
+		mode, err = executor.fileSystem.Mode(filePath)
+		if err != nil {
+			return err
+		}
+	}
+	err = executor.fileSystem.WriteFile(filePath, content, mode)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = append(executor.rollbackSteps, func() error {
+		if !existed {
+			return executor.fileSystem.Remove(filePath)
+		}
+		return executor.fileSystem.WriteFile(filePath, originalContent, mode)
+	})
+	return nil
+}
+
+// Rewrites large file applying the literal replacement at given offsets, without loading the file into memory.
+func (executor *executor) rewriteFile(file string, offsets []int64, replacement *literalReplacement) error {
+	if err := executor.ctx.Err(); err != nil {
+		return err
+	}
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would write file %s.\n", file)
+		return err
+	}
+
+	fileSystem := executor.fileSystem.(StreamingFileSystem)
+	filePath := path.Join(executor.baseDir, file)
+	err := rewriteFile(fileSystem, filePath, offsets, len(replacement.old), replacement.new)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = append(executor.rollbackSteps, func() error {
+		rewrittenOffsets := shiftOffsets(offsets, len(replacement.old), len(replacement.new))
+		return rewriteFile(fileSystem, filePath, rewrittenOffsets, len(replacement.new), replacement.old)
+	})
+	return nil
+}
+
+func (executor *executor) fileExists(file string) (bool, error) {
+	_, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, file))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Returns given files without the ones ignored by git. Git can only tell about files stored on the local disk, so other
+// file systems are not filtered.
+func (executor *executor) skipIgnored(files []string) ([]string, error) {
+	if _, ok := executor.fileSystem.(*OSFileSystem); !ok {
+		return files, nil
+	}
+	ignored, err := executor.git.Ignored(executor.ctx, files...)
+	if err != nil {
+		return nil, err
+	}
+	ignoredSet := map[string]bool{}
+	for _, file := range ignored {
+		ignoredSet[file] = true
+	}
+	var kept []string
+	for _, file := range files {
+		if !ignoredSet[file] {
+			kept = append(kept, file)
+		}
+	}
+	return kept, nil
+}
+
+func (executor *executor) gitAdd(files ...string) error {
+	// Failed add can stage part of the files, so the index is restored even if add fails.
+	executor.rollbackSteps = append(executor.rollbackSteps, func() error {
+		return executor.git.Unstage(context.Background(), files...)
+	})
+	return executor.git.Add(executor.ctx, files...)
+}
+
+func (executor *executor) gitCommit(message string, options *GitCommitOptions) error {
+	previousHead, err := executor.headCommit()
+	if err != nil {
+		return err
+	}
+	err = executor.git.Commit(executor.ctx, message, options)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = append(executor.rollbackSteps, func() error {
+		return executor.git.Reset(context.Background(), previousHead)
+	})
+	sha, err := executor.headCommit()
+	if err != nil {
+		return err
+	}
+	return executor.events.OnCommit(executor.ctx, sha, message)
+}
+
+func (executor *executor) gitTag(name string, options *GitTagOptions) error {
+	err := executor.git.Tag(executor.ctx, name, options)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = append(executor.rollbackSteps, func() error {
+		return executor.git.DeleteTag(context.Background(), name)
+	})
+	return executor.events.OnTag(executor.ctx, name)
+}
+
+func (executor *executor) gitPush(remote string, branch string) error {
+	err := executor.events.OnPush(executor.ctx, remote, branch)
+	if err != nil {
+		return err
+	}
+	err = executor.git.Push(executor.ctx, remote, branch)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = nil
+	return nil
+}
+
+func (executor *executor) gitPushTags(remote string) error {
+	err := executor.git.PushTags(executor.ctx, remote)
+	if err != nil {
+		return err
+	}
+	executor.rollbackSteps = nil
+	return nil
+}
+
+// Reverts executed operations in reverse order. Returns the cause of the rollback, extended with the rollback failure
+// if any.
+func (executor *executor) rollback(cause error) error {
+	for i := len(executor.rollbackSteps) - 1; i >= 0; i-- {
+		err := executor.rollbackSteps[i]()
+		if err != nil {
+			executor.rollbackSteps = executor.rollbackSteps[:i]
+			return fmt.Errorf("%w (rollback failed: %s)", cause, err)
+		}
+	}
+	executor.rollbackSteps = nil
+	return cause
+}
+
+// Returns SHA of the current HEAD commit. In dry run mode empty string is returned.
+func (executor *executor) headCommit() (string, error) {
+	if executor.dryRun {
+		return "", nil
+	}
+	return executor.git.Head(executor.ctx)
+}
@@ -0,0 +1,185 @@
+package vrs
+
+import (
+	"fmt"
+	"path"
+)
+
+// Reads config file and the configs it extends. Extended config is resolved relatively to the directory of the file
+// extending it.
+func readConfigFile(fileSystem FileSystem, file string, visited map[string]bool) (*VrsConfig, error) {
+	yml, err := fileSystem.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	yml, err = migrateConfig(yml)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config %s: %w", file, err)
+	}
+	config, err := parseConfig(yml)
+	if err != nil {
+		return nil, err
+	}
+	if config.Extends == "" {
+		return config, nil
+	}
+
+	visited[path.Clean(file)] = true
+	baseFile := path.Join(path.Dir(file), config.Extends)
+	if visited[path.Clean(baseFile)] {
+		return nil, fmt.Errorf("config %s extends itself through %s", file, config.Extends)
+	}
+	base, err := readConfigFile(fileSystem, baseFile, visited)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config %s extended by %s: %w", baseFile, file, err)
+	}
+	return config.inherit(base), nil
+}
+
+// Returns config with the settings missing in this config taken from the base config. Sync files and hooks are merged:
+// local sync file replaces inherited one of the same name and local hooks replace inherited hooks of the same stage.
+func (config *VrsConfig) inherit(base *VrsConfig) *VrsConfig {
+	merged := *config
+	if config.declared == nil {
+		merged.declared = config
+	}
+	merged.Version = firstNonEmpty(config.Version, base.Version)
+	merged.Scheme = firstNonEmpty(config.Scheme, base.Scheme)
+	merged.Format = firstNonEmpty(config.Format, base.Format)
+	if merged.Segments == 0 {
+		merged.Segments = base.Segments
+	}
+	merged.CommitMessage = firstNonEmpty(config.CommitMessage, base.CommitMessage)
+	merged.FileMode = firstNonEmpty(config.FileMode, base.FileMode)
+	merged.VersionPrefix = firstNonEmpty(config.VersionPrefix, base.VersionPrefix)
+	merged.VersionSource = firstNonEmpty(config.VersionSource, base.VersionSource)
+	if merged.Metadata == nil {
+		merged.Metadata = base.Metadata
+	}
+	if merged.Tag == nil {
+		merged.Tag = base.Tag
+	}
+	if merged.Commit == nil {
+		merged.Commit = base.Commit
+	}
+	if merged.BuildNumber == nil {
+		merged.BuildNumber = base.BuildNumber
+	}
+	if merged.Push == nil {
+		merged.Push = base.Push
+	}
+	if merged.Profiles == nil {
+		merged.Profiles = base.Profiles
+	}
+	if merged.Changelog == nil {
+		merged.Changelog = base.Changelog
+	}
+	if merged.Notes == nil {
+		merged.Notes = base.Notes
+	}
+	if merged.GitHub == nil {
+		merged.GitHub = base.GitHub
+	}
+	if merged.GitLab == nil {
+		merged.GitLab = base.GitLab
+	}
+	if merged.Gitea == nil {
+		merged.Gitea = base.Gitea
+	}
+	if merged.Notify == nil {
+		merged.Notify = base.Notify
+	}
+	if merged.Components == nil {
+		merged.Components = base.Components
+	}
+	merged.Sync = config.Sync.inherit(base.Sync)
+	merged.Hooks = config.Hooks.inherit(base.Hooks)
+	return &merged
+}
+
+func (sync *Sync) inherit(base *Sync) *Sync {
+	if sync == nil {
+		return base
+	}
+	if base == nil {
+		return sync
+	}
+	merged := &Sync{}
+	for _, file := range base.Files {
+		if !sync.hasFile(file.Name) {
+			merged.Files = append(merged.Files, file)
+		}
+	}
+	merged.Files = append(merged.Files, sync.Files...)
+	merged.Exclude = append(append(merged.Exclude, base.Exclude...), sync.Exclude...)
+	return merged
+}
+
+func (sync *Sync) hasFile(name string) bool {
+	for _, file := range sync.Files {
+		if file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (hooks *Hooks) inherit(base *Hooks) *Hooks {
+	if hooks == nil {
+		return base
+	}
+	if base == nil {
+		return hooks
+	}
+	merged := *hooks
+	if merged.PreBump == nil {
+		merged.PreBump = base.PreBump
+	}
+	if merged.PostBump == nil {
+		merged.PostBump = base.PostBump
+	}
+	if merged.PostTag == nil {
+		merged.PostTag = base.PostTag
+	}
+	return &merged
+}
+
+// Returns the config as declared in its file, without inherited settings, carrying the current versions of the config
+// and its inline components.
+func (config *VrsConfig) declaredConfig() *VrsConfig {
+	declared := *config
+	if config.declared != nil {
+		declared = *config.declared
+	}
+	declared.Version = config.prefixedVersion(config.Version)
+	declared.Components = make([]*Component, len(declared.Components))
+	for i, component := range config.declaredComponents() {
+		declaredComponent := *component
+		if declaredComponent.Path == "" {
+			declaredComponent.Version = config.prefixedVersion(config.componentVersion(component.Name, component.Version))
+		}
+		declared.Components[i] = &declaredComponent
+	}
+	if len(declared.Components) == 0 {
+		declared.Components = nil
+	}
+	return &declared
+}
+
+func (config *VrsConfig) declaredComponents() []*Component {
+	if config.declared != nil {
+		return config.declared.Components
+	}
+	return config.Components
+}
+
+// Returns current version of the component with given name, or the default version if the config has no such
+// component.
+func (config *VrsConfig) componentVersion(name string, defaultVersion string) string {
+	for _, component := range config.Components {
+		if component.Name == name {
+			return component.Version
+		}
+	}
+	return defaultVersion
+}
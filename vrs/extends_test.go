@@ -0,0 +1,42 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBumpWithExtendedConfig(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"shared/base.yml": []byte("scheme: semver\nsync:\n  files:\n  - name: VERSION\n  - name: chart.yml\n    type: yaml\n    path: $.version\n"),
+		"app/vrs.yml": []byte("extends: ../shared/base.yml\nversion: 1.0.0\nsync:\n  files:\n  - name: chart.yml\n" +
+			"    type: yaml\n    path: $.appVersion\n"),
+		"app/VERSION":   []byte("1.0.0"),
+		"app/chart.yml": []byte("version: 0.1.0\nappVersion: 1.0.0\n"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: "app", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "app/VERSION"))
+	assert.Equal(t, "version: 0.1.0\nappVersion: 1.1.0\n", readInMemory(t, fileSystem, "app/chart.yml"))
+	assert.Equal(t, "extends: ../shared/base.yml\nversion: 1.1.0\nsync:\n  files:\n  - name: chart.yml\n"+
+		"    type: yaml\n    path: $.appVersion\n", readInMemory(t, fileSystem, "app/vrs.yml"))
+}
+
+func TestFailOnCyclicExtends(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":  []byte("extends: base.yml\nversion: 1.0.0\n"),
+		"base.yml": []byte("extends: vrs.yml\n"),
+	})
+
+	// When
+	_, err := vrs.ParseVersioonConfigFS(fileSystem, ".")
+
+	// Then
+	assert.EqualError(t, err, "cannot read config base.yml extended by vrs.yml: config base.yml extends itself through vrs.yml")
+}
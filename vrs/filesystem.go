@@ -0,0 +1,157 @@
+package vrs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// File operations used by vrs to read and update project files. Implement it to run vrs against files not stored on
+// the local disk.
+type FileSystem interface {
+	// Returns error satisfying os.IsNotExist if the file doesn't exist.
+	ReadFile(name string) ([]byte, error)
+	// Writes data to the file and sets its permissions, both for new and existing files.
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	// Returns permission bits of the file. Returns error satisfying os.IsNotExist if the file doesn't exist.
+	Mode(name string) (os.FileMode, error)
+	Remove(name string) error
+	// Returns slash separated paths of all files under given directory, relative to it. The .git directory is skipped.
+	ListFiles(dir string) ([]string, error)
+}
+
+// FileSystem backed by the local disk.
+type OSFileSystem struct {
+}
+
+func (fileSystem *OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(name)
+}
+
+func (fileSystem *OSFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	err := ioutil.WriteFile(name, data, perm)
+	if err != nil {
+		return err
+	}
+	// Permissions of existing files are not changed by ioutil.WriteFile.
+	return os.Chmod(name, perm)
+}
+
+func (fileSystem *OSFileSystem) Mode(name string) (os.FileMode, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return 0, err
+	}
+	return info.Mode().Perm(), nil
+}
+
+func (fileSystem *OSFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (fileSystem *OSFileSystem) ListFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relativePath))
+		return nil
+	})
+	return files, err
+}
+
+// FileSystem keeping files in memory. It is safe for concurrent use.
+type MemoryFileSystem struct {
+	mutex sync.RWMutex
+	files map[string][]byte
+	modes map[string]os.FileMode
+}
+
+// Creates in-memory file system with given initial files. Keys of the map are file paths. Initial files have
+// DefaultFileMode permissions.
+func NewMemoryFileSystem(files map[string][]byte) *MemoryFileSystem {
+	fileSystem := &MemoryFileSystem{files: map[string][]byte{}, modes: map[string]os.FileMode{}}
+	for name, data := range files {
+		fileSystem.files[path.Clean(name)] = append([]byte{}, data...)
+		fileSystem.modes[path.Clean(name)] = DefaultFileMode
+	}
+	return fileSystem
+}
+
+func (fileSystem *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	fileSystem.mutex.RLock()
+	defer fileSystem.mutex.RUnlock()
+	data, ok := fileSystem.files[path.Clean(name)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return append([]byte{}, data...), nil
+}
+
+func (fileSystem *MemoryFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	fileSystem.mutex.Lock()
+	defer fileSystem.mutex.Unlock()
+	fileSystem.files[path.Clean(name)] = append([]byte{}, data...)
+	fileSystem.modes[path.Clean(name)] = perm.Perm()
+	return nil
+}
+
+func (fileSystem *MemoryFileSystem) Mode(name string) (os.FileMode, error) {
+	fileSystem.mutex.RLock()
+	defer fileSystem.mutex.RUnlock()
+	mode, ok := fileSystem.modes[path.Clean(name)]
+	if !ok {
+		return 0, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return mode, nil
+}
+
+func (fileSystem *MemoryFileSystem) Remove(name string) error {
+	fileSystem.mutex.Lock()
+	defer fileSystem.mutex.Unlock()
+	if _, ok := fileSystem.files[path.Clean(name)]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	delete(fileSystem.files, path.Clean(name))
+	delete(fileSystem.modes, path.Clean(name))
+	return nil
+}
+
+func (fileSystem *MemoryFileSystem) ListFiles(dir string) ([]string, error) {
+	fileSystem.mutex.RLock()
+	defer fileSystem.mutex.RUnlock()
+	prefix := path.Clean(dir) + "/"
+	if prefix == "./" {
+		prefix = ""
+	}
+	var files []string
+	for name := range fileSystem.files {
+		if strings.HasPrefix(name, prefix) && !strings.HasPrefix(name, prefix+".git/") {
+			files = append(files, strings.TrimPrefix(name, prefix))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func fileSystemOrDefault(fileSystem FileSystem) FileSystem {
+	if fileSystem == nil {
+		return &OSFileSystem{}
+	}
+	return fileSystem
+}
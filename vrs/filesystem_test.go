@@ -0,0 +1,231 @@
+package vrs_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestBumpInMemory(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"project/vrs.yml":     []byte("version: 1.0.0\nsync:\n  files:\n  - name: version.txt\n"),
+		"project/version.txt": []byte("version=1.0.0\n"),
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: "project", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: "project", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", version)
+	synced, err := fileSystem.ReadFile("project/version.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "version=1.1.0\n", string(synced))
+}
+
+func TestNoVersioonFileInMemory(t *testing.T) {
+	// When
+	_, err := vrs.ParseVersioonConfigFS(vrs.NewMemoryFileSystem(nil), "project")
+
+	// Then
+	assert.Equal(t, vrs.NoVersioonFileFound, err)
+}
+
+func TestFailWhenGlobMatchesNoFiles(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: '**/package.json'\n"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrSyncFileMissing))
+}
+
+func TestExcludeFilesFromGlobSync(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":                            []byte("version: 1.0.0\nsync:\n  files:\n  - name: '**/package.json'\n  exclude:\n  - '**/node_modules/**'\n"),
+		"package.json":                       []byte(`{"version": "1.0.0"}`),
+		"web/package.json":                   []byte(`{"version": "1.0.0"}`),
+		"web/node_modules/left/package.json": []byte(`{"version": "1.0.0"}`),
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, result.SyncedFiles, 2)
+	dependency, err := fileSystem.ReadFile("web/node_modules/left/package.json")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version": "1.0.0"}`, string(dependency))
+}
+
+func TestSyncDirectory(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":               []byte("version: 1.0.0\nsync:\n  files:\n  - directory: docs\n    filter: '*.md'\n"),
+		"docs/index.md":         []byte("Install 1.0.0."),
+		"docs/guide/install.md": []byte("Install 1.0.0."),
+		"docs/guide/image.svg":  []byte("1.0.0"),
+		"readme.md":             []byte("Install 1.0.0."),
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, result.SyncedFiles, 2)
+	guide, err := fileSystem.ReadFile("docs/guide/install.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "Install 1.1.0.", string(guide))
+	readme, err := fileSystem.ReadFile("readme.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "Install 1.0.0.", string(readme))
+}
+
+func TestKeepFilePermissions(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", FileMode: "0640", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "build.sh"}}}}).Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "build.sh"), []byte("#!/bin/sh\necho 1.0.0\n"), 0755)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	scriptInfo, err := os.Stat(path.Join(basedir, "build.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), scriptInfo.Mode().Perm())
+	configInfo, err := os.Stat(path.Join(basedir, vrs.VrsConfigFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), configInfo.Mode().Perm())
+}
+
+func TestSkipBinaryFilesMatchingGlob(t *testing.T) {
+	// Given
+	binary := []byte("\x00\x01version 1.0.0")
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":        []byte("version: 1.0.0\nsync:\n  files:\n  - name: assets/*\n"),
+		"assets/app.txt": []byte("1.0.0"),
+		"assets/app.png": binary,
+	})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"skipped binary file assets/app.png matching assets/*"}, result.Warnings)
+	content, err := fileSystem.ReadFile("assets/app.png")
+	assert.NoError(t, err)
+	assert.Equal(t, binary, content)
+}
+
+func TestFailOnListedBinaryFile(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: app.png\n"),
+		"app.png": []byte("\x00\x011.0.0"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrBinarySyncFile))
+}
+
+func TestSyncManyFiles(t *testing.T) {
+	// Given
+	files := map[string][]byte{"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: '**/*.txt'\n")}
+	for i := 0; i < 200; i++ {
+		files[fmt.Sprintf("dir%d/file%d.txt", i%10, i)] = []byte("1.0.0")
+	}
+	fileSystem := vrs.NewMemoryFileSystem(files)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, result.SyncedFiles, 200)
+	for i := 0; i < 200; i++ {
+		content, err := fileSystem.ReadFile(fmt.Sprintf("dir%d/file%d.txt", i%10, i))
+		assert.NoError(t, err)
+		assert.Equal(t, "1.1.0", string(content))
+	}
+}
+
+func TestReportAllSyncErrors(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: a.txt\n  - name: b.txt\n  - name: c.txt\n    required: true\n"),
+		"b.txt":   []byte("1.0.0"),
+		"c.txt":   []byte("none"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrSyncFileMissing))
+	assert.True(t, errors.Is(err, vrs.ErrSyncPatternNotMatched))
+	content, err := fileSystem.ReadFile("b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", string(content))
+}
+
+func TestSyncLargeFile(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	defer os.RemoveAll(basedir)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "app.log"}}}}).Write(basedir)
+	assert.NoError(t, err)
+	logFile, err := os.Create(path.Join(basedir, "app.log"))
+	assert.NoError(t, err)
+	_, err = logFile.WriteString("started 1.0.0\n" + strings.Repeat("\n", 8000))
+	assert.NoError(t, err)
+	// Sparse file with versions at the chunk boundary and at the end of the file. Text at the beginning of the file
+	// keeps it from being detected as binary.
+	_, err = logFile.WriteAt([]byte("1.0.0"), 64<<10-2)
+	assert.NoError(t, err)
+	_, err = logFile.WriteAt([]byte("stopped 1.0.0\n"), 65<<20)
+	assert.NoError(t, err)
+	assert.NoError(t, logFile.Close())
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.SyncedFiles[0].Replacements)
+	logFile, err = os.Open(path.Join(basedir, "app.log"))
+	assert.NoError(t, err)
+	defer logFile.Close()
+	for offset, expected := range map[int64]string{0: "started 1.1.0\n", 64<<10 - 2: "1.1.0", 65 << 20: "stopped 1.1.0\n"} {
+		content := make([]byte, len(expected))
+		_, err = logFile.ReadAt(content, offset)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+}
@@ -0,0 +1,157 @@
+package vrs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Git hosting service (GitHub, GitLab, Gitea or Forgejo) creating releases of the pushed tags.
+type forge interface {
+	// Name of the service used in messages.
+	name() string
+	// Verifies that the release can be created, so the release won't fail after the tag is pushed.
+	check(executor *executor) error
+	// Returns false if the release tag is created by the service together with the release instead of being pushed.
+	pushesTags() bool
+	tagExists(ctx context.Context, tag string) (bool, error)
+	// Creates the release and returns URL of its page.
+	createRelease(executor *executor, release *forgeRelease) (string, error)
+}
+
+type forgeRelease struct {
+	tag string
+	// Tagged commit. Empty in dry run mode.
+	commit     string
+	tagMessage string
+	notes      string
+	prerelease bool
+}
+
+// Resource requested from the API of git hosting service doesn't exist.
+var errForgeResourceNotFound = errors.New("resource not found")
+
+// Returns git hosting services configured to create releases.
+func (config *VrsConfig) forges() []forge {
+	var forges []forge
+	if config.GitHub != nil && config.GitHub.Release {
+		forges = append(forges, config.GitHub)
+	}
+	if config.GitLab != nil && config.GitLab.Release {
+		forges = append(forges, config.GitLab)
+	}
+	if config.Gitea != nil && config.Gitea.Release {
+		forges = append(forges, config.Gitea)
+	}
+	return forges
+}
+
+// Verifies that releases configured for git hosting services can be created, before anything is pushed.
+func (config *VrsConfig) checkReleases(executor *executor) error {
+	for _, forge := range config.forges() {
+		err := forge.check(executor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns true if the release tag is pushed with git, rather than created by the API of git hosting service.
+func (config *VrsConfig) pushTagsWithGit() bool {
+	for _, forge := range config.forges() {
+		if !forge.pushesTags() {
+			return false
+		}
+	}
+	return true
+}
+
+// Creates releases of the tag in git hosting services. Pushed tag is verified to exist in the hosted repository first.
+// Ref is the tagged commit.
+func (config *VrsConfig) publishReleases(executor *executor, gitOptions *GitOptions, tag string, ref string, result *BumpResult) error {
+	forges := config.forges()
+	if len(forges) == 0 {
+		return nil
+	}
+	if executor.dryRun {
+		for _, forge := range forges {
+			_, err := fmt.Fprintf(executor.output, "Would create %s release %s.\n", forge.name(), tag)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	notes, err := config.renderNotes(executor, gitOptions, "")
+	if err != nil {
+		return err
+	}
+	tagOptions, err := config.tagOptions(executor, gitOptions)
+	if err != nil {
+		return err
+	}
+	version, versionErr := ParseVersion(config.Version)
+	release := &forgeRelease{tag: tag, commit: ref, tagMessage: tagOptions.Message, notes: notes, prerelease: versionErr == nil && version.Prerelease != ""}
+	for _, forge := range forges {
+		if forge.pushesTags() {
+			exists, err := forge.tagExists(executor.ctx, tag)
+			if err != nil {
+				return fmt.Errorf("cannot verify tag %s in %s repository: %w", tag, forge.name(), err)
+			}
+			if !exists {
+				return fmt.Errorf("tag %s not found in %s repository", tag, forge.name())
+			}
+		}
+		result.ReleaseURL, err = forge.createRelease(executor, release)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Calls HTTP API of the git hosting service and decodes JSON response into the result. Name of the service is used in
+// error messages.
+func callAPI(ctx context.Context, service string, method string, requestURL string, header http.Header, body io.Reader, result interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s API responded with %s", errForgeResourceNotFound, service, response.Status)
+	}
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("%s API responded with %s: %s", service, response.Status, strings.TrimSpace(string(responseBody)))
+	}
+	if len(responseBody) == 0 || result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
+
+// Returns true if the API call succeeded and false if the resource was not found.
+func forgeResourceExists(err error) (bool, error) {
+	if errors.Is(err, errForgeResourceNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,144 @@
+package vrs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Numeric placeholders of the custom format, ordered from the most significant one.
+var formatSegments = []string{MajorSegment, MinorSegment, PatchSegment, RevisionSegment}
+
+var formatPlaceholder = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9_]*)\}`)
+
+// Versioning scheme of versions rendered with custom format, for example {major}.{minor}.{patch}-{channel} renders
+// versions like 1.4.0-stable. Format consists of placeholders in braces separated by literal characters. The major,
+// minor, patch and revision placeholders are numbers bumped by the segment of the same name, with lower ones reset to
+// zero. Values of other placeholders are alphanumeric and kept when the version is bumped.
+type CustomFormat struct {
+	Format string
+}
+
+type customFormatPart struct {
+	placeholder string
+	literal     string
+}
+
+func (format *CustomFormat) Next(current string, kind BumpKind) (string, error) {
+	parts, expression, err := format.compile()
+	if err != nil {
+		return "", err
+	}
+	values, err := format.match(parts, expression, current)
+	if err != nil {
+		return "", err
+	}
+	if kind == "" {
+		kind = MinorSegment
+	}
+	bumped := -1
+	for i, segment := range formatSegments {
+		if string(kind) == segment {
+			bumped = i
+		}
+	}
+	if bumped < 0 {
+		return "", fmt.Errorf("unknown version segment %q (expected %s)", kind, strings.Join(formatSegments, ", "))
+	}
+	if _, ok := values[string(kind)]; !ok {
+		return "", fmt.Errorf("format %s has no {%s} placeholder", format.Format, kind)
+	}
+
+	next := ""
+	for _, part := range parts {
+		value, ok := values[part.placeholder]
+		if !ok {
+			next += part.literal
+			continue
+		}
+		for i, segment := range formatSegments {
+			if part.placeholder == segment && i == bumped {
+				number, _ := strconv.Atoi(value)
+				value = strconv.Itoa(number + 1)
+			} else if part.placeholder == segment && i > bumped {
+				value = "0"
+			}
+		}
+		next += value
+	}
+	return next, nil
+}
+
+func (format *CustomFormat) Validate(version string) error {
+	parts, expression, err := format.compile()
+	if err != nil {
+		return err
+	}
+	_, err = format.match(parts, expression, version)
+	return err
+}
+
+func (format *CustomFormat) compile() ([]customFormatPart, *regexp.Regexp, error) {
+	var parts []customFormatPart
+	expression := "^"
+	seen := map[string]bool{}
+	position := 0
+	for _, location := range formatPlaceholder.FindAllStringSubmatchIndex(format.Format, -1) {
+		literal := format.Format[position:location[0]]
+		if literal != "" {
+			parts = append(parts, customFormatPart{literal: literal})
+			expression += regexp.QuoteMeta(literal)
+		}
+		placeholder := format.Format[location[2]:location[3]]
+		if seen[placeholder] {
+			return nil, nil, fmt.Errorf("format %s contains placeholder {%s} more than once", format.Format, placeholder)
+		}
+		seen[placeholder] = true
+		parts = append(parts, customFormatPart{placeholder: placeholder})
+		if isFormatSegment(placeholder) {
+			expression += `(0|[1-9][0-9]*)`
+		} else {
+			expression += `([0-9A-Za-z]+)`
+		}
+		position = location[1]
+	}
+	if len(seen) == 0 {
+		return nil, nil, fmt.Errorf("format %s contains no placeholders", format.Format)
+	}
+	if literal := format.Format[position:]; literal != "" {
+		parts = append(parts, customFormatPart{literal: literal})
+		expression += regexp.QuoteMeta(literal)
+	}
+	r, err := regexp.Compile(expression + "$")
+	if err != nil {
+		return nil, nil, err
+	}
+	return parts, r, nil
+}
+
+// Returns values of the placeholders of the version.
+func (format *CustomFormat) match(parts []customFormatPart, expression *regexp.Regexp, version string) (map[string]string, error) {
+	matches := expression.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("%w %q: expected format %s", ErrInvalidVersion, version, format.Format)
+	}
+	values := map[string]string{}
+	group := 1
+	for _, part := range parts {
+		if part.placeholder != "" {
+			values[part.placeholder] = matches[group]
+			group++
+		}
+	}
+	return values, nil
+}
+
+func isFormatSegment(placeholder string) bool {
+	for _, segment := range formatSegments {
+		if placeholder == segment {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+package vrs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const DefaultTagTemplate = "v{{.Version}}"
+
+const DefaultTagMessageTemplate = "Version {{.Version}}."
+
+type Tag struct {
+	Template string `yaml:",omitempty"`
+	// Creates annotated tag instead of the lightweight one.
+	Annotated bool `yaml:",omitempty"`
+	// Template of the annotated tag message.
+	Message string `yaml:",omitempty"`
+	// Appends changelog section of the version to the tag message, so it is displayed by git show. Implies annotated
+	// tag.
+	Changelog bool `yaml:",omitempty"`
+	// Signs the tag using GPG or SSH key (depending on gpg.format git setting). Signed tags are always annotated.
+	Sign       bool   `yaml:",omitempty"`
+	SigningKey string `yaml:"signingKey,omitempty"`
+}
+
+type Commit struct {
+	// Signs release commit using GPG or SSH key (depending on gpg.format git setting).
+	Sign       bool   `yaml:",omitempty"`
+	SigningKey string `yaml:"signingKey,omitempty"`
+	// Skips release commit, tag and push with a warning if git is not installed or the project is not in git
+	// repository, instead of failing the release.
+	Optional bool `yaml:",omitempty"`
+}
+
+type Push struct {
+	Remote string `yaml:",omitempty"`
+	Branch string `yaml:",omitempty"`
+	// Keeps release commits and tags local, as if the push was disabled with the command line flag.
+	Disabled bool `yaml:",omitempty"`
+}
+
+// Git settings of operations creating release commits and tags. Empty values fall back to the settings from vrs.yml.
+type GitOptions struct {
+	// Template of the tag name, for example release-{{.Version}}.
+	TagTemplate string
+	AnnotateTag bool
+	TagMessage  string
+	// Appends changelog section of the version to the annotated tag message.
+	TagChangelog  bool
+	SignTag       bool
+	TagSigningKey string
+	SignCommit    bool
+	// Key used to sign release commit. Implies commit signing.
+	CommitSigningKey string
+	// Template of the release commit message, for example "chore(release): {{.NewVersion}} (was {{.OldVersion}})".
+	CommitMessage string
+	// Remote repository to push release commit and tag to. Defaults to the remote configured for current branch.
+	Remote string
+	// Remote branch to push release commit to. Defaults to the upstream of current branch.
+	Branch string
+	// Allows release when working tree contains uncommitted changes of files not managed by vrs.
+	AllowDirty bool
+	// Client executing git operations. Defaults to GoGitClient working with the repository of the project directory.
+	GitClient GitClient
+}
+
+// Renders release commit message using template from options or config. Default message is used if no template has
+// been configured.
+func (config *VrsConfig) commitMessage(executor *executor, gitOptions *GitOptions, defaultMessage string) (string, error) {
+	messageTemplate := config.CommitMessage
+	if gitOptions != nil && gitOptions.CommitMessage != "" {
+		messageTemplate = gitOptions.CommitMessage
+	}
+	if messageTemplate == "" {
+		return defaultMessage, nil
+	}
+	return renderTemplate("commit message", messageTemplate, config.templateData(executor, config.Version))
+}
+
+func (config *VrsConfig) tagName(executor *executor, gitOptions *GitOptions) (string, error) {
+	return renderTemplate("tag", config.tagTemplate(gitOptions), config.templateData(executor, config.tagVersion()))
+}
+
+// Returns glob pattern matching release tags of all versions, for example v*.
+func (config *VrsConfig) tagPattern(executor *executor, gitOptions *GitOptions) (string, error) {
+	return renderTemplate("tag", config.tagTemplate(gitOptions), config.templateData(executor, "*"))
+}
+
+func (config *VrsConfig) tagTemplate(gitOptions *GitOptions) string {
+	if gitOptions != nil && gitOptions.TagTemplate != "" {
+		return gitOptions.TagTemplate
+	}
+	if config.Tag != nil && config.Tag.Template != "" {
+		return config.Tag.Template
+	}
+	if config.component != nil {
+		return DefaultComponentTagTemplate
+	}
+	return DefaultTagTemplate
+}
+
+// Returns options of git tag command creating the release tag.
+func (config *VrsConfig) tagOptions(executor *executor, gitOptions *GitOptions) (*GitTagOptions, error) {
+	tagConfig := config.Tag
+	if tagConfig == nil {
+		tagConfig = &Tag{}
+	}
+	if gitOptions == nil {
+		gitOptions = &GitOptions{}
+	}
+
+	options := &GitTagOptions{
+		Sign:       gitOptions.SignTag || tagConfig.Sign,
+		SigningKey: firstNonEmpty(gitOptions.TagSigningKey, tagConfig.SigningKey),
+	}
+	changelog := gitOptions.TagChangelog || tagConfig.Changelog
+	if !gitOptions.AnnotateTag && !tagConfig.Annotated && !changelog && !options.Sign && options.SigningKey == "" {
+		return options, nil
+	}
+
+	messageTemplate := firstNonEmpty(gitOptions.TagMessage, tagConfig.Message, DefaultTagMessageTemplate)
+	message, err := renderTemplate("tag message", messageTemplate, config.templateData(executor, config.tagVersion()))
+	if err != nil {
+		return nil, err
+	}
+	if changelog {
+		_, _, commits, err := config.versionCommits(executor, gitOptions)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read commits of the tag changelog: %w", err)
+		}
+		message = strings.TrimRight(message, "\n") + "\n\n" + renderChangelogSection(config.tagVersion(), time.Now(), commits)
+	}
+	options.Message = message
+	return options, nil
+}
+
+// Returns options of git commit command creating the release commit.
+func (config *VrsConfig) commitOptions(gitOptions *GitOptions) *GitCommitOptions {
+	commitConfig := config.Commit
+	if commitConfig == nil {
+		commitConfig = &Commit{}
+	}
+	if gitOptions == nil {
+		gitOptions = &GitOptions{}
+	}
+
+	return &GitCommitOptions{
+		Sign:       gitOptions.SignCommit || commitConfig.Sign,
+		SigningKey: firstNonEmpty(gitOptions.CommitSigningKey, commitConfig.SigningKey),
+	}
+}
+
+// Returns remote and branch configured for push. Empty values mean git defaults. Branch without remote is pushed to
+// origin.
+func (config *VrsConfig) pushTarget(gitOptions *GitOptions) (string, string) {
+	pushConfig := config.Push
+	if pushConfig == nil {
+		pushConfig = &Push{}
+	}
+	if gitOptions == nil {
+		gitOptions = &GitOptions{}
+	}
+
+	remote, branch := firstNonEmpty(gitOptions.Remote, pushConfig.Remote), firstNonEmpty(gitOptions.Branch, pushConfig.Branch)
+	if remote == "" && branch != "" {
+		remote = "origin"
+	}
+	return remote, branch
+}
+
+// Returns whether release should be pushed, given the push requested by the options.
+func (config *VrsConfig) pushEnabled(push bool) bool {
+	return push && (config.Push == nil || !config.Push.Disabled)
+}
+
+// Verifies that the tag doesn't exist in the remote repository yet, so the release won't fail halfway on push. In dry
+// run mode problems are reported as warnings.
+func (config *VrsConfig) checkRemoteTag(executor *executor, gitOptions *GitOptions, tag string) error {
+	remote, _ := config.pushTarget(gitOptions)
+	exists, err := executor.git.RemoteTagExists(executor.ctx, remote, tag)
+	if err != nil {
+		err = fmt.Errorf("cannot list tags of remote repository: %w", err)
+	} else if exists {
+		err = fmt.Errorf("tag %s already exists in remote repository", tag)
+	}
+	if err != nil && executor.dryRun {
+		_, err = fmt.Fprintf(executor.output, "Warning: %s.\n", err)
+	}
+	return err
+}
+
+// Verifies up front that git steps of the release can be executed. Returns ErrGitNotInstalled or ErrNotGitRepository
+// otherwise.
+func verifyGitRepository(executor *executor) error {
+	_, err := executor.git.Status(executor.ctx)
+	var commandErr *GitCommandError
+	if errors.Is(err, ErrNotGitRepository) || errors.As(err, &commandErr) && strings.Contains(commandErr.Stderr, "not a git repository") {
+		return fmt.Errorf("%w: %s is outside of git repository, initialize it with git init", ErrNotGitRepository, executor.baseDir)
+	}
+	if errors.Is(err, ErrGitNotInstalled) {
+		return err
+	}
+	return nil
+}
+
+// Returns whether the release should be committed. If git is not available and commit is optional, the release is
+// not committed and the warning is reported in the result. In dry run mode problems are reported as warnings.
+func (config *VrsConfig) gitAvailable(executor *executor, result *BumpResult) (bool, error) {
+	err := verifyGitRepository(executor)
+	if err == nil {
+		return true, nil
+	}
+	if executor.dryRun {
+		_, err = fmt.Fprintf(executor.output, "Warning: %s.\n", err)
+		return true, err
+	}
+	if config.Commit == nil || !config.Commit.Optional {
+		return false, err
+	}
+	result.Warnings = append(result.Warnings, fmt.Sprintf("release is not committed: %s", err))
+	return false, nil
+}
+
+// Verifies that working tree contains no uncommitted changes except of the expected files, so the release commit won't
+// accidentally include unrelated changes. Untracked files are ignored as they are never committed by vrs. In dry run
+// mode problems are reported as warnings.
+func checkWorkingTree(executor *executor, gitOptions *GitOptions, expectedFiles []string) error {
+	if gitOptions != nil && gitOptions.AllowDirty {
+		return nil
+	}
+	err := verifyWorkingTree(executor, expectedFiles)
+	if err != nil && executor.dryRun {
+		_, err = fmt.Fprintf(executor.output, "Warning: %s.\n", err)
+	}
+	return err
+}
+
+func verifyWorkingTree(executor *executor, expectedFiles []string) error {
+	files, err := executor.git.Status(executor.ctx)
+	if err != nil {
+		return fmt.Errorf("cannot read status of working tree: %w", err)
+	}
+
+	var dirtyFiles []string
+	for _, file := range files {
+		expected := false
+		for _, expectedFile := range expectedFiles {
+			if matchGlob(expectedFile, file) {
+				expected = true
+				break
+			}
+		}
+		if !expected {
+			dirtyFiles = append(dirtyFiles, file)
+		}
+	}
+	if len(dirtyFiles) > 0 {
+		return fmt.Errorf("working tree contains uncommitted changes of files: %s", strings.Join(dirtyFiles, ", "))
+	}
+	return nil
+}
+
+// Returns template variables of the executed bump extended with the component of the config.
+func (config *VrsConfig) templateData(executor *executor, version string) *templateData {
+	data := executor.templateData(version)
+	if config.component != nil {
+		data.Component = config.component.Name
+	}
+	return data
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func renderTemplate(name string, text string, data interface{}) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	rendered := &bytes.Buffer{}
+	err = t.Execute(rendered, data)
+	if err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
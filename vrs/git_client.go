@@ -0,0 +1,423 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Git operations used by vrs to commit, tag and publish releases. Implement it to mock git in tests or to replace git
+// executable with another implementation.
+type GitClient interface {
+	// Returns files with uncommitted changes. Untracked files are ignored.
+	Status(ctx context.Context) ([]string, error)
+	// Returns SHA of the current HEAD commit or empty string if repository has no commits yet.
+	Head(ctx context.Context) (string, error)
+	// Returns name of the current branch or empty string if HEAD is detached.
+	Branch(ctx context.Context) (string, error)
+	Add(ctx context.Context, files ...string) error
+	// Removes given files from the index, reverting Add.
+	Unstage(ctx context.Context, files ...string) error
+	Commit(ctx context.Context, message string, options *GitCommitOptions) error
+	// Moves HEAD to given commit keeping working tree and index intact. Empty commit removes HEAD, reverting the first
+	// commit of the repository.
+	Reset(ctx context.Context, commit string) error
+	Tag(ctx context.Context, name string, options *GitTagOptions) error
+	DeleteTag(ctx context.Context, name string) error
+	// Pushes current HEAD. Empty remote and branch mean git defaults.
+	Push(ctx context.Context, remote string, branch string) error
+	PushTags(ctx context.Context, remote string) error
+	RemoteTagExists(ctx context.Context, remote string, tag string) (bool, error)
+	// Returns those of given files which are ignored by git. Outside of git repository no files are ignored.
+	Ignored(ctx context.Context, files ...string) ([]string, error)
+	// Returns the highest version tag matching given glob pattern (for example v*) reachable from the revision. Empty
+	// revision means HEAD. Empty string is returned if there is no such tag or the revision doesn't exist.
+	LastTag(ctx context.Context, revision string, pattern string) (string, error)
+	// Returns commits reachable from until revision but not from since revision, newest first. Empty until revision
+	// means HEAD, empty since revision returns the whole history.
+	Log(ctx context.Context, since string, until string) ([]*GitLogEntry, error)
+}
+
+// Commit returned by GitClient.Log.
+type GitLogEntry struct {
+	SHA     string
+	Subject string
+	// Message of the commit without the subject line.
+	Body string
+}
+
+type GitCommitOptions struct {
+	Sign       bool
+	SigningKey string
+}
+
+type GitTagOptions struct {
+	// Message of the annotated tag. Empty message creates lightweight tag unless the tag is signed.
+	Message    string
+	Sign       bool
+	SigningKey string
+}
+
+// GitClient executing git binary in given directory.
+type ExecGitClient struct {
+	Dir string
+}
+
+func NewExecGitClient(dir string) *ExecGitClient {
+	return &ExecGitClient{Dir: dir}
+}
+
+func (client *ExecGitClient) Status(ctx context.Context) ([]string, error) {
+	status, err := client.run(ctx, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		file := line[3:]
+		if arrowIndex := strings.Index(file, " -> "); arrowIndex >= 0 {
+			file = file[arrowIndex+4:]
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func (client *ExecGitClient) Head(ctx context.Context) (string, error) {
+	head, err := client.run(ctx, "rev-parse", "--verify", "-q", "HEAD")
+	if err != nil {
+		// Verification of missing HEAD fails silently.
+		if _, statusErr := client.run(ctx, "status", "--porcelain"); statusErr == nil {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(head), nil
+}
+
+func (client *ExecGitClient) Branch(ctx context.Context) (string, error) {
+	branch, err := client.run(ctx, "symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		// Detached HEAD is reported by exit code 1 without error message.
+		var commandErr *GitCommandError
+		if errors.As(err, &commandErr) && commandErr.Stderr == "" {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(branch), nil
+}
+
+func (client *ExecGitClient) Add(ctx context.Context, files ...string) error {
+	_, err := client.run(ctx, addArgs(files)...)
+	return err
+}
+
+func (client *ExecGitClient) Unstage(ctx context.Context, files ...string) error {
+	head, err := client.Head(ctx)
+	if err != nil {
+		return err
+	}
+	if head == "" {
+		_, err = client.run(ctx, append([]string{"rm", "--cached", "-q", "--ignore-unmatch", "--"}, files...)...)
+		return err
+	}
+	_, err = client.run(ctx, append([]string{"reset", "-q", "--"}, files...)...)
+	return err
+}
+
+func (client *ExecGitClient) Commit(ctx context.Context, message string, options *GitCommitOptions) error {
+	_, err := client.run(ctx, commitArgs(message, options)...)
+	return err
+}
+
+func (client *ExecGitClient) Reset(ctx context.Context, commit string) error {
+	if commit == "" {
+		_, err := client.run(ctx, "update-ref", "-d", "HEAD")
+		return err
+	}
+	_, err := client.run(ctx, "reset", "-q", "--soft", commit)
+	return err
+}
+
+func (client *ExecGitClient) Tag(ctx context.Context, name string, options *GitTagOptions) error {
+	_, err := client.run(ctx, tagArgs(name, options)...)
+	return err
+}
+
+func (client *ExecGitClient) DeleteTag(ctx context.Context, name string) error {
+	_, err := client.run(ctx, "tag", "-d", name)
+	return err
+}
+
+func (client *ExecGitClient) Push(ctx context.Context, remote string, branch string) error {
+	_, err := client.run(ctx, pushArgs(remote, branch)...)
+	return err
+}
+
+func (client *ExecGitClient) PushTags(ctx context.Context, remote string) error {
+	_, err := client.run(ctx, pushTagsArgs(remote)...)
+	return err
+}
+
+func (client *ExecGitClient) RemoteTagExists(ctx context.Context, remote string, tag string) (bool, error) {
+	if remote == "" {
+		var err error
+		remote, err = client.pushRemote(ctx)
+		if err != nil {
+			return false, err
+		}
+	}
+	output, err := client.run(ctx, "ls-remote", "--tags", remote, "refs/tags/"+tag)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+func (client *ExecGitClient) Ignored(ctx context.Context, files ...string) ([]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	if _, err := client.run(ctx, "rev-parse", "--git-dir"); err != nil {
+		return nil, nil
+	}
+	output, err := client.runWithInput(ctx, strings.Join(files, "\x00"), "check-ignore", "-z", "--stdin")
+	if err != nil {
+		// Exit status 1 means that none of the files is ignored.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ignored []string
+	for _, file := range strings.Split(output, "\x00") {
+		if file != "" {
+			ignored = append(ignored, file)
+		}
+	}
+	return ignored, nil
+}
+
+func (client *ExecGitClient) LastTag(ctx context.Context, revision string, pattern string) (string, error) {
+	commit, err := client.commit(ctx, revision)
+	if err != nil || commit == "" {
+		return "", err
+	}
+	output, err := client.run(ctx, "tag", "--merged", commit, "--sort=-v:refname", "--list", pattern)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(strings.SplitN(output, "\n", 2)[0]), nil
+}
+
+func (client *ExecGitClient) Log(ctx context.Context, since string, until string) ([]*GitLogEntry, error) {
+	commit, err := client.commit(ctx, until)
+	if err != nil || commit == "" {
+		return nil, err
+	}
+	revisions := commit
+	if since != "" {
+		revisions = since + ".." + commit
+	}
+	output, err := client.run(ctx, "log", "--format=%H%x00%B%x1e", revisions, "--")
+	if err != nil {
+		return nil, err
+	}
+	var entries []*GitLogEntry
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		message := strings.SplitN(strings.TrimSpace(fields[1]), "\n", 2)
+		entry := &GitLogEntry{SHA: fields[0], Subject: message[0]}
+		if len(message) == 2 {
+			entry.Body = strings.TrimSpace(message[1])
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Returns SHA of the commit the revision points to. Empty revision means HEAD. Empty string is returned if the revision
+// doesn't exist.
+func (client *ExecGitClient) commit(ctx context.Context, revision string) (string, error) {
+	if revision == "" {
+		return client.Head(ctx)
+	}
+	commit, err := client.run(ctx, "rev-parse", "--verify", "-q", revision+"^{commit}")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// Returns remote used by git push without arguments: push remote of the current branch, default push remote, remote of
+// the current branch or origin.
+func (client *ExecGitClient) pushRemote(ctx context.Context) (string, error) {
+	var keys []string
+	branch, err := client.run(ctx, "symbolic-ref", "--short", "-q", "HEAD")
+	if err == nil && strings.TrimSpace(branch) != "" {
+		branch = strings.TrimSpace(branch)
+		keys = append(keys, "branch."+branch+".pushRemote", "remote.pushDefault", "branch."+branch+".remote")
+	} else {
+		keys = append(keys, "remote.pushDefault")
+	}
+	for _, key := range keys {
+		// Missing config key fails with empty output.
+		remote, err := client.run(ctx, "config", "--get", key)
+		if err == nil && strings.TrimSpace(remote) != "" {
+			return strings.TrimSpace(remote), nil
+		}
+	}
+	return "origin", nil
+}
+
+func (client *ExecGitClient) run(ctx context.Context, args ...string) (string, error) {
+	return client.runWithInput(ctx, "", args...)
+}
+
+func (client *ExecGitClient) runWithInput(ctx context.Context, input string, args ...string) (string, error) {
+	// #nosec - Git arguments are controlled by vrs.
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = client.Dir
+	cmd.Stdin = strings.NewReader(input)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%w: install git or provide custom GitClient", ErrGitNotInstalled)
+		}
+		if ctx.Err() != nil {
+			return "", &GitCommandError{Args: args, Err: ctx.Err()}
+		}
+		return "", &GitCommandError{Args: args, Stderr: stderr.String(), Err: err}
+	}
+	return string(output), nil
+}
+
+func addArgs(files []string) []string {
+	return append([]string{"add"}, files...)
+}
+
+func commitArgs(message string, options *GitCommitOptions) []string {
+	if options == nil {
+		options = &GitCommitOptions{}
+	}
+	args := []string{"commit"}
+	if options.SigningKey != "" {
+		args = append(args, "--gpg-sign="+options.SigningKey)
+	} else if options.Sign {
+		args = append(args, "-S")
+	}
+	return append(args, "-m", message)
+}
+
+func tagArgs(name string, options *GitTagOptions) []string {
+	if options == nil {
+		options = &GitTagOptions{}
+	}
+	args := []string{"tag"}
+	switch {
+	case options.SigningKey != "":
+		args = append(args, "-u", options.SigningKey)
+	case options.Sign:
+		args = append(args, "-s")
+	case options.Message != "":
+		args = append(args, "-a")
+	default:
+		return append(args, name)
+	}
+	// Git strips lines starting with # from the message by default, which would remove headings of the changelog.
+	if strings.HasPrefix(options.Message, "#") || strings.Contains(options.Message, "\n#") {
+		args = append(args, "--cleanup=whitespace")
+	}
+	return append(args, "-m", options.Message, name)
+}
+
+func pushArgs(remote string, branch string) []string {
+	if remote == "" && branch == "" {
+		return []string{"push"}
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	args := []string{"push", remote}
+	if branch != "" {
+		args = append(args, "HEAD:refs/heads/"+branch)
+	}
+	return args
+}
+
+func pushTagsArgs(remote string) []string {
+	if remote == "" {
+		return []string{"push", "--tags"}
+	}
+	return []string{"push", remote, "--tags"}
+}
+
+// GitClient reporting changing operations to the output instead of executing them. Queries are delegated, so dry run
+// reflects the actual state of the repository.
+type dryRunGitClient struct {
+	GitClient
+	output io.Writer
+}
+
+func (client *dryRunGitClient) Add(ctx context.Context, files ...string) error {
+	return client.report(addArgs(files))
+}
+
+func (client *dryRunGitClient) Unstage(ctx context.Context, files ...string) error {
+	return nil
+}
+
+func (client *dryRunGitClient) Commit(ctx context.Context, message string, options *GitCommitOptions) error {
+	return client.report(commitArgs(message, options))
+}
+
+func (client *dryRunGitClient) Reset(ctx context.Context, commit string) error {
+	return nil
+}
+
+func (client *dryRunGitClient) Tag(ctx context.Context, name string, options *GitTagOptions) error {
+	return client.report(tagArgs(name, options))
+}
+
+func (client *dryRunGitClient) DeleteTag(ctx context.Context, name string) error {
+	return nil
+}
+
+func (client *dryRunGitClient) Push(ctx context.Context, remote string, branch string) error {
+	return client.report(pushArgs(remote, branch))
+}
+
+func (client *dryRunGitClient) PushTags(ctx context.Context, remote string) error {
+	return client.report(pushTagsArgs(remote))
+}
+
+func (client *dryRunGitClient) report(args []string) error {
+	quotedArgs := make([]string, len(args))
+	for i, arg := range args {
+		quotedArgs[i] = arg
+		if strings.ContainsAny(arg, " \t\n\"'") {
+			quotedArgs[i] = strconv.Quote(arg)
+		}
+	}
+	_, err := fmt.Fprintf(client.output, "Would execute: git %s\n", strings.Join(quotedArgs, " "))
+	return err
+}
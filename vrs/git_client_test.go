@@ -0,0 +1,210 @@
+package vrs_test
+
+import (
+	"context"
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+type recordingGitClient struct {
+	calls  []string
+	tagErr error
+	branch string
+}
+
+func (client *recordingGitClient) Status(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (client *recordingGitClient) Head(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (client *recordingGitClient) Branch(ctx context.Context) (string, error) {
+	return client.branch, nil
+}
+
+func (client *recordingGitClient) Add(ctx context.Context, files ...string) error {
+	client.calls = append(client.calls, "add")
+	return nil
+}
+
+func (client *recordingGitClient) Unstage(ctx context.Context, files ...string) error {
+	client.calls = append(client.calls, "unstage")
+	return nil
+}
+
+func (client *recordingGitClient) Commit(ctx context.Context, message string, options *vrs.GitCommitOptions) error {
+	client.calls = append(client.calls, "commit "+message)
+	return nil
+}
+
+func (client *recordingGitClient) Reset(ctx context.Context, commit string) error {
+	client.calls = append(client.calls, "reset")
+	return nil
+}
+
+func (client *recordingGitClient) Tag(ctx context.Context, name string, options *vrs.GitTagOptions) error {
+	if client.tagErr != nil {
+		return client.tagErr
+	}
+	client.calls = append(client.calls, "tag "+name)
+	return nil
+}
+
+func (client *recordingGitClient) DeleteTag(ctx context.Context, name string) error {
+	client.calls = append(client.calls, "delete tag "+name)
+	return nil
+}
+
+func (client *recordingGitClient) Push(ctx context.Context, remote string, branch string) error {
+	client.calls = append(client.calls, "push")
+	return nil
+}
+
+func (client *recordingGitClient) PushTags(ctx context.Context, remote string) error {
+	client.calls = append(client.calls, "push tags")
+	return nil
+}
+
+func (client *recordingGitClient) RemoteTagExists(ctx context.Context, remote string, tag string) (bool, error) {
+	return false, nil
+}
+
+func (client *recordingGitClient) Ignored(ctx context.Context, files ...string) ([]string, error) {
+	return nil, nil
+}
+
+func (client *recordingGitClient) LastTag(ctx context.Context, revision string, pattern string) (string, error) {
+	return "", nil
+}
+
+func (client *recordingGitClient) Log(ctx context.Context, since string, until string) ([]*vrs.GitLogEntry, error) {
+	return nil, nil
+}
+
+func TestBumpWithCustomGitClient(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0"}).Write(basedir)
+	assert.NoError(t, err)
+	client := &recordingGitClient{}
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: client}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", result.Tag)
+	assert.Equal(t, []string{"add", "commit Version bump.", "tag v1.1.0", "push", "push tags"}, client.calls)
+}
+
+func TestRollbackWithCustomGitClient(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0"}).Write(basedir)
+	assert.NoError(t, err)
+	client := &recordingGitClient{tagErr: errors.New("tag failed")}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitOptions: vrs.GitOptions{GitClient: client}})
+
+	// Then
+	assert.EqualError(t, err, "tag failed")
+	assert.Equal(t, []string{"add", "commit Version bump.", "reset", "unstage"}, client.calls)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestFailWithoutGitExecutable(t *testing.T) {
+	// Given
+	emptyPath, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	setenv(t, "PATH", emptyPath)
+
+	// When
+	_, err = vrs.NewExecGitClient(emptyPath).Head(context.Background())
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrGitNotInstalled))
+}
+
+func TestReadCurrentBranch(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "checkout", "-b", "hotfix/login")
+	client := vrs.NewExecGitClient(basedir)
+
+	// When
+	branch, err := client.Branch(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "hotfix/login", branch)
+	gitOutput(t, basedir, "checkout", "--detach")
+	branch, err = client.Branch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "", branch)
+}
+
+func TestBumpWithoutGitExecutable(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	emptyPath, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	gitPath := os.Getenv("PATH")
+	setenv(t, "PATH", emptyPath)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true,
+		GitOptions: vrs.GitOptions{AnnotateTag: true, TagMessage: "Release {{.Version}}."}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.1.0", result.Tag)
+	setenv(t, "PATH", gitPath)
+	assert.Equal(t, "Version bump.\n", gitOutput(t, basedir, "log", "-1", "--format=%s"))
+	assert.Equal(t, "Release 1.1.0.\n", gitOutput(t, basedir, "tag", "-l", "--format=%(contents:subject)", "v1.1.0"))
+	assert.Empty(t, gitOutput(t, basedir, "status", "--porcelain"))
+}
+
+func TestReadLastTagByVersion(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	for _, tag := range []string{"v1.2.0", "v1.10.0", "v1.9.0", "other-2.0.0"} {
+		gitOutput(t, basedir, "tag", tag)
+	}
+	client := vrs.NewGoGitClient(basedir)
+
+	// When
+	tag, err := client.LastTag(context.Background(), "", "v*")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.10.0", tag)
+}
+
+func TestGoGitClientReadsCurrentBranch(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "checkout", "-b", "hotfix/login")
+	client := vrs.NewGoGitClient(basedir)
+
+	// When
+	branch, err := client.Branch(context.Background())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "hotfix/login", branch)
+	gitOutput(t, basedir, "checkout", "--detach")
+	branch, err = client.Branch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "", branch)
+}
@@ -0,0 +1,533 @@
+package vrs_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+	"time"
+)
+
+func newGitProject(t *testing.T, config *vrs.VrsConfig) string {
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", basedir).Run()
+	assert.NoError(t, err)
+	err = config.WriteAndCommit(basedir, true, false, "Initial commit.")
+	assert.NoError(t, err)
+	return basedir
+}
+
+func gitOutput(t *testing.T, basedir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = basedir
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+	return string(output)
+}
+
+// Sets environment variable for the duration of the test, like testing.T.Setenv available since Go 1.17.
+func setenv(t *testing.T, key string, value string) {
+	previous, exists := os.LookupEnv(key)
+	assert.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if exists {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestTagTemplateFromConfig(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Tag: &vrs.Tag{Template: "release-{{.Version}}"}})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "release-1.1.0", result.Tag)
+	assert.Equal(t, "release-1.0.0\nrelease-1.1.0\n", gitOutput(t, basedir, "tag"))
+}
+
+func TestTagTemplateFromOptions(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Tag: &vrs.Tag{Template: "release-{{.Version}}"}})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitOptions: vrs.GitOptions{TagTemplate: "{{.Version}}"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.Tag)
+}
+
+func TestInvalidTagTemplate(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitOptions: vrs.GitOptions{TagTemplate: "{{.Unknown}}"}})
+
+	// Then
+	assert.Error(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestAnnotatedTag(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Tag: &vrs.Tag{Annotated: true, Message: "Release {{.Version}}"}})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "tag\n", gitOutput(t, basedir, "cat-file", "-t", "v1.1.0"))
+	assert.Equal(t, "Release 1.1.0\n\n", gitOutput(t, basedir, "tag", "-l", "--format=%(contents)", "v1.1.0"))
+}
+
+func TestTagWithChangelog(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Tag: &vrs.Tag{Changelog: true}})
+	commitFile(t, basedir, "parser.go", "fix(parser): handle empty input")
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "Version 1.1.0.\n\n## [1.1.0] - "+time.Now().Format("2006-01-02")+"\n\n### Fixed\n\n- **parser:** handle empty input\n\n",
+		gitOutput(t, basedir, "tag", "-l", "--format=%(contents)", "v1.1.0"))
+}
+
+func TestSignedTag(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	output := &bytes.Buffer{}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, DryRun: true, DryRunOutput: output,
+		GitOptions: vrs.GitOptions{SignTag: true, TagSigningKey: "ABCDEF"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), `Would execute: git tag -u ABCDEF -m "Version 1.1.0." v1.1.0`)
+}
+
+func TestSignedCommit(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	err := (&vrs.VrsConfig{Version: "1.0.0", Commit: &vrs.Commit{Sign: true}}).Write(basedir)
+	assert.NoError(t, err)
+	output := &bytes.Buffer{}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, DryRun: true, DryRunOutput: output})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), `Would execute: git commit -S -m "Version bump."`)
+}
+
+func TestCommitSigningKey(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	output := &bytes.Buffer{}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, DryRun: true, DryRunOutput: output,
+		GitOptions: vrs.GitOptions{CommitSigningKey: "ABCDEF"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), `Would execute: git commit --gpg-sign=ABCDEF -m "Version bump."`)
+}
+
+func TestCommitMessageTemplate(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", CommitMessage: "chore(release): {{.NewVersion}} (was {{.OldVersion}})"})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "chore(release): 1.1.0 (was 1.0.0)\n", gitOutput(t, basedir, "log", "-1", "--format=%s"))
+}
+
+func TestCommitMessageTemplateFromOptions(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", CommitMessage: "Release {{.NewVersion}}"})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, ActiveProfiles: []string{"docker"},
+		GitOptions: vrs.GitOptions{CommitMessage: "Release {{.NewVersion}} with profiles {{range .Profiles}}{{.}}{{end}}"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "Release 1.1.0 with profiles docker\n", gitOutput(t, basedir, "log", "-1", "--format=%s"))
+}
+
+func TestTemplateVariables(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", CommitMessage: "Release {{.Major}}.{{.Minor}} of {{.CommitSHA}}",
+		Tag: &vrs.Tag{Template: "v{{.NewVersion}}-{{.Date}}"}})
+	head := gitOutput(t, basedir, "rev-parse", "HEAD")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "Release 1.1 of "+head, gitOutput(t, basedir, "log", "-1", "--format=%s"))
+	assert.Equal(t, "v1.1.0-"+time.Now().Format("2006-01-02"), result.Tag)
+}
+
+func TestPushToConfiguredRemoteAndBranch(t *testing.T) {
+	// Given
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "remote", "add", "upstream", remote)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Push: &vrs.Push{Remote: "upstream"}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{Branch: "release-1.x"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "Version bump.\n", gitOutput(t, remote, "log", "-1", "--format=%s", "release-1.x"))
+	assert.Contains(t, gitOutput(t, remote, "tag"), "v1.1.0")
+}
+
+func TestFailWhenTagExistsInRemote(t *testing.T) {
+	// Given
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "remote", "add", "origin", remote)
+	gitOutput(t, basedir, "tag", "v1.1.0")
+	gitOutput(t, basedir, "push", "origin", "v1.1.0")
+	gitOutput(t, basedir, "tag", "-d", "v1.1.0")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{Remote: "origin"}})
+
+	// Then
+	assert.EqualError(t, err, "tag v1.1.0 already exists in remote repository")
+	assert.Equal(t, "1\n", gitOutput(t, basedir, "rev-list", "--count", "HEAD"))
+	assert.Empty(t, gitOutput(t, basedir, "status", "--porcelain"))
+}
+
+func TestFailWhenTagExistsInDefaultRemote(t *testing.T) {
+	// Given
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "remote", "add", "origin", remote)
+	gitOutput(t, basedir, "tag", "v1.1.0")
+	gitOutput(t, basedir, "push", "origin", "v1.1.0")
+	gitOutput(t, basedir, "tag", "-d", "v1.1.0")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true})
+
+	// Then
+	assert.EqualError(t, err, "tag v1.1.0 already exists in remote repository")
+}
+
+func TestPushToDefaultRemote(t *testing.T) {
+	// Given
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "remote", "add", "upstream", remote)
+	gitOutput(t, basedir, "push", "-u", "upstream", "HEAD")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, gitOutput(t, remote, "tag"), "v1.1.0")
+}
+
+func TestRollbackFailedAdd(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "ignored.txt"}}}})
+	err := ioutil.WriteFile(path.Join(basedir, ".gitignore"), []byte("ignored.txt\n"), 0600)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "ignored.txt"), []byte("1.0.0"), 0600)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", ".gitignore")
+	gitOutput(t, basedir, "commit", "-m", "Ignore file.")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.Error(t, err)
+	assert.Empty(t, gitOutput(t, basedir, "status", "--porcelain"))
+	assert.Empty(t, gitOutput(t, basedir, "diff", "--cached", "--name-only"))
+}
+
+func TestSkipIgnoredFilesMatchingGlob(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "**/package.json"}}}})
+	err := ioutil.WriteFile(path.Join(basedir, ".gitignore"), []byte("node_modules/\n"), 0600)
+	assert.NoError(t, err)
+	err = os.MkdirAll(path.Join(basedir, "node_modules", "dep"), 0700)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "node_modules", "dep", "package.json"), []byte(`{"version": "1.0.0"}`), 0600)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "package.json"), []byte(`{"version": "1.0.0"}`), 0600)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", ".gitignore", "package.json")
+	gitOutput(t, basedir, "commit", "-m", "Add package.")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, result.SyncedFiles, 1)
+	dependency, err := ioutil.ReadFile(path.Join(basedir, "node_modules", "dep", "package.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version": "1.0.0"}`, string(dependency))
+}
+
+func TestFailOnDirtyWorkingTree(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	err := ioutil.WriteFile(path.Join(basedir, "main.go"), []byte("package main"), 0600)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", "main.go")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.EqualError(t, err, "working tree contains uncommitted changes of files: main.go")
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestAllowDirtyWorkingTree(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	err := ioutil.WriteFile(path.Join(basedir, "main.go"), []byte("package main"), 0600)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", "main.go")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitOptions: vrs.GitOptions{AllowDirty: true}})
+
+	// Then
+	assert.NoError(t, err)
+}
+
+func TestGitErrorContainsCommandOutput(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "tag", "v1.1.0")
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "git tag failed:")
+	assert.Contains(t, err.Error(), "already exists")
+	assert.True(t, errors.Is(err, vrs.ErrGitCommandFailed))
+	var gitErr *vrs.GitCommandError
+	assert.True(t, errors.As(err, &gitErr))
+	assert.Equal(t, "tag", gitErr.Args[0])
+	assert.Contains(t, gitErr.Stderr, "already exists")
+}
+
+func TestFailCommitOutsideOfGitRepository(t *testing.T) {
+	// Given
+	basedir := t.TempDir()
+	err := ioutil.WriteFile(path.Join(basedir, "vrs.yml"), []byte("version: 1.0.0\n"), 0644)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrNotGitRepository))
+	assert.Contains(t, err.Error(), "initialize it with git init")
+	config, err := ioutil.ReadFile(path.Join(basedir, "vrs.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 1.0.0\n", string(config))
+}
+
+func TestSkipOptionalCommitOutsideOfGitRepository(t *testing.T) {
+	// Given
+	basedir := t.TempDir()
+	err := ioutil.WriteFile(path.Join(basedir, "vrs.yml"), []byte("version: 1.0.0\ncommit:\n  optional: true\n"), 0644)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+	assert.Empty(t, result.Tag)
+	assert.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "release is not committed")
+}
+
+func TestReleaseSnapshot(t *testing.T) {
+	// Given
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.5.0-SNAPSHOT", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "pom.xml", Type: vrs.XMLSyncType}}}})
+	err = ioutil.WriteFile(path.Join(basedir, "pom.xml"), []byte("<project><version>1.5.0-SNAPSHOT</version></project>"), 0600)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", "pom.xml")
+	gitOutput(t, basedir, "commit", "-m", "Add pom.")
+	gitOutput(t, basedir, "remote", "add", "origin", remote)
+
+	// When
+	result, err := vrs.Release(&vrs.ReleaseOptions{Basedir: basedir, GitPush: true, GitOptions: vrs.GitOptions{Branch: "main"}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", result.NewVersion)
+	assert.Equal(t, "1.5.1-SNAPSHOT", result.DevelopmentVersion)
+	assert.Len(t, result.Commits, 2)
+	assert.Equal(t, "Next development version.\nVersion release.\n", gitOutput(t, remote, "log", "-2", "--format=%s", "main"))
+	assert.Equal(t, "<project><version>1.5.0</version></project>", gitOutput(t, remote, "show", "v1.5.0:pom.xml"))
+	assert.Equal(t, "<project><version>1.5.1-SNAPSHOT</version></project>", gitOutput(t, basedir, "show", "HEAD:pom.xml"))
+}
+
+func TestFailToReleaseNonSnapshotVersion(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.5.0"})
+
+	// When
+	_, err := vrs.Release(&vrs.ReleaseOptions{Basedir: basedir})
+
+	// Then
+	assert.EqualError(t, err, "version 1.5.0 is not a SNAPSHOT version")
+}
+
+func commitFile(t *testing.T, basedir string, name string, message string) {
+	err := ioutil.WriteFile(path.Join(basedir, name), []byte(message), 0644)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", name)
+	gitOutput(t, basedir, "commit", "-m", message)
+}
+
+func TestBumpSegmentFromConventionalCommits(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	commitFile(t, basedir, "parser.go", "fix(parser): handle empty input")
+	commitFile(t, basedir, "api.go", "feat: add streaming API")
+	commitFile(t, basedir, "readme.md", "docs: describe streaming API")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, Segment: vrs.AutoSegment})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+}
+
+func TestBumpMajorSegmentOnBreakingChange(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	commitFile(t, basedir, "api.go", "refactor: rename API\n\nBREAKING CHANGE: Parse function renamed to Read.")
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, Segment: vrs.AutoSegment})
+	assert.NoError(t, err)
+	commitFile(t, basedir, "parser.go", "fix: handle empty input")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, Segment: vrs.AutoSegment})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.1", result.NewVersion)
+}
+
+func TestGenerateChangelog(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Changelog: &vrs.Changelog{}})
+	commitFile(t, basedir, "parser.go", "fix(parser): handle empty input")
+	commitFile(t, basedir, "api.go", "feat: add streaming API")
+	commitFile(t, basedir, "readme.md", "docs: describe streaming API")
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	changelog, err := ioutil.ReadFile(path.Join(basedir, vrs.DefaultChangelogFile))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\nAll notable changes to this project will be documented in this file.\n\n"+
+		"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).\n\n"+
+		"## [1.1.0] - "+time.Now().Format("2006-01-02")+"\n\n### Added\n\n- add streaming API\n\n"+
+		"### Fixed\n\n- **parser:** handle empty input\n", string(changelog))
+	assert.Equal(t, "", gitOutput(t, basedir, "status", "--porcelain"))
+}
+
+func TestAddChangelogSection(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Changelog: &vrs.Changelog{File: "HISTORY.md"}})
+	err := ioutil.WriteFile(path.Join(basedir, "HISTORY.md"), []byte("# History\n\n## [Unreleased]\n\n## [1.0.0] - 2020-01-01\n\n- Initial release.\n"), 0644)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "add", "HISTORY.md")
+	gitOutput(t, basedir, "commit", "-m", "docs: add history")
+	commitFile(t, basedir, "api.go", "fix: close streams")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	changelog, err := ioutil.ReadFile(path.Join(basedir, "HISTORY.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# History\n\n## [Unreleased]\n\n## [1.1.0] - "+time.Now().Format("2006-01-02")+"\n\n### Fixed\n\n"+
+		"- close streams\n\n## [1.0.0] - 2020-01-01\n\n- Initial release.\n", string(changelog))
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	commitFile(t, basedir, "api.go", "feat: add streaming API (#12)")
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+	assert.NoError(t, err)
+	commitFile(t, basedir, "parser.go", "fix: handle empty input")
+
+	// When
+	notes, err := vrs.RenderNotes(&vrs.NotesOptions{Basedir: basedir,
+		Template: "{{.PreviousTag}}..{{.Tag}}\n{{range .Commits}}{{with .Conventional}}{{.Type}} {{end}}{{.Subject}} {{.PullRequests}}\n{{end}}"})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.0.0..v1.1.0\nVersion bump. []\nfeat feat: add streaming API (#12) [12]\n", notes)
+}
@@ -0,0 +1,87 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Settings of the release created in self-hosted Gitea or Forgejo after the release tag is pushed. API token is read
+// from GITEA_TOKEN environment variable, or FORGEJO_TOKEN if the former is not set.
+type Gitea struct {
+	// Creates release of the pushed tag, using release notes as its description.
+	Release bool `yaml:",omitempty"`
+	// Base URL of Gitea or Forgejo instance, for example https://codeberg.org.
+	URL string `yaml:"url,omitempty"`
+	// Repository in owner/name format.
+	Repository string `yaml:",omitempty"`
+	// Creates draft release, which has to be published manually.
+	Draft bool `yaml:",omitempty"`
+}
+
+type giteaRelease struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type giteaReleaseResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+func (gitea *Gitea) name() string {
+	return "Gitea"
+}
+
+func (gitea *Gitea) check(executor *executor) error {
+	if gitea.URL == "" || gitea.Repository == "" {
+		return fmt.Errorf("Gitea release requires gitea.url and gitea.repository in %s", VrsConfigFileName)
+	}
+	if gitea.token() == "" {
+		return fmt.Errorf("GITEA_TOKEN or FORGEJO_TOKEN environment variable is required to create Gitea release")
+	}
+	return nil
+}
+
+func (gitea *Gitea) pushesTags() bool {
+	return true
+}
+
+func (gitea *Gitea) token() string {
+	return firstNonEmpty(os.Getenv("GITEA_TOKEN"), os.Getenv("FORGEJO_TOKEN"))
+}
+
+func (gitea *Gitea) repositoryURL() string {
+	return strings.TrimSuffix(gitea.URL, "/") + "/api/v1/repos/" + gitea.Repository
+}
+
+func (gitea *Gitea) header() http.Header {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Authorization", "token "+gitea.token())
+	return header
+}
+
+func (gitea *Gitea) tagExists(ctx context.Context, tag string) (bool, error) {
+	return forgeResourceExists(callAPI(ctx, "Gitea", http.MethodGet, gitea.repositoryURL()+"/tags/"+url.PathEscape(tag), gitea.header(), nil, nil))
+}
+
+func (gitea *Gitea) createRelease(executor *executor, release *forgeRelease) (string, error) {
+	body, err := json.Marshal(&giteaRelease{TagName: release.tag, Name: release.tag, Body: release.notes, Draft: gitea.Draft, Prerelease: release.prerelease})
+	if err != nil {
+		return "", err
+	}
+	response := &giteaReleaseResponse{}
+	err = callAPI(executor.ctx, "Gitea", http.MethodPost, gitea.repositoryURL()+"/releases", gitea.header(), bytes.NewReader(body), response)
+	if err != nil {
+		return "", fmt.Errorf("cannot create Gitea release %s: %w", release.tag, err)
+	}
+	return response.HTMLURL, nil
+}
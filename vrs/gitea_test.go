@@ -0,0 +1,44 @@
+package vrs_test
+
+import (
+	"encoding/json"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateGiteaRelease(t *testing.T) {
+	// Given
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/api/v1/repos/org/app/tags/v1.0.0-rc.2", r.URL.Path)
+			_, _ = writer.Write([]byte(`{"name": "v1.0.0-rc.2"}`))
+			return
+		}
+		assert.Equal(t, "/api/v1/repos/org/app/releases", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		writer.WriteHeader(http.StatusCreated)
+		_, _ = writer.Write([]byte(`{"html_url": "https://codeberg.org/org/app/releases/tag/v1.0.0-rc.2"}`))
+	}))
+	defer server.Close()
+	setenv(t, "GITEA_TOKEN", "")
+	setenv(t, "FORGEJO_TOKEN", "secret")
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0-rc.1", Gitea: &vrs.Gitea{Release: true, URL: server.URL, Repository: "org/app"}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, Segment: vrs.PrereleaseSegment, GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "https://codeberg.org/org/app/releases/tag/v1.0.0-rc.2", result.ReleaseURL)
+	assert.Equal(t, "v1.0.0-rc.2", request["tag_name"])
+	assert.Equal(t, true, request["prerelease"])
+}
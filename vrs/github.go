@@ -0,0 +1,169 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// Settings of GitHub release created after the release tag is pushed. API token is read from GITHUB_TOKEN environment
+// variable.
+type GitHub struct {
+	// Creates GitHub release of the pushed tag, using release notes as its description.
+	Release bool `yaml:",omitempty"`
+	// Repository in owner/name format. Defaults to GITHUB_REPOSITORY environment variable set by GitHub Actions.
+	Repository string `yaml:",omitempty"`
+	// URL of GitHub API, for example https://github.example.com/api/v3 for GitHub Enterprise Server.
+	APIURL string `yaml:"apiUrl,omitempty"`
+	// Creates draft release, which has to be published manually.
+	Draft bool `yaml:",omitempty"`
+	// Glob patterns of the files uploaded to the release, for example dist/*.tar.gz. SHA-256 checksums of the files
+	// are uploaded as DefaultChecksumsAsset.
+	Assets []string `yaml:",omitempty"`
+}
+
+// Name of the release asset listing SHA-256 checksums of the uploaded files in the format of sha256sum.
+const DefaultChecksumsAsset = "checksums.txt"
+
+type gitHubRelease struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type gitHubReleaseResponse struct {
+	ID        int64  `json:"id"`
+	HTMLURL   string `json:"html_url"`
+	UploadURL string `json:"upload_url"`
+}
+
+func (github *GitHub) check(executor *executor) error {
+	_, err := expandAssets(executor, github.Assets)
+	if err != nil {
+		return err
+	}
+	if github.repository() == "" {
+		return fmt.Errorf("GitHub repository is not configured: set github.repository in %s or GITHUB_REPOSITORY environment variable", VrsConfigFileName)
+	}
+	if os.Getenv("GITHUB_TOKEN") == "" {
+		return fmt.Errorf("GITHUB_TOKEN environment variable is required to create GitHub release")
+	}
+	return nil
+}
+
+func (github *GitHub) repository() string {
+	return firstNonEmpty(github.Repository, os.Getenv("GITHUB_REPOSITORY"))
+}
+
+func (github *GitHub) name() string {
+	return "GitHub"
+}
+
+func (github *GitHub) pushesTags() bool {
+	return true
+}
+
+func (github *GitHub) apiURL() string {
+	return strings.TrimSuffix(firstNonEmpty(github.APIURL, DefaultGitHubAPIURL), "/") + "/repos/" + github.repository()
+}
+
+func (github *GitHub) tagExists(ctx context.Context, tag string) (bool, error) {
+	return forgeResourceExists(github.call(ctx, http.MethodGet, github.apiURL()+"/git/ref/tags/"+url.PathEscape(tag), "application/json", nil, nil))
+}
+
+// Creates GitHub release of the tag and uploads its assets.
+func (github *GitHub) createRelease(executor *executor, release *forgeRelease) (string, error) {
+	assets, err := expandAssets(executor, github.Assets)
+	if err != nil {
+		return "", err
+	}
+	request, err := json.Marshal(&gitHubRelease{TagName: release.tag, Name: release.tag, Body: release.notes, Draft: github.Draft, Prerelease: release.prerelease})
+	if err != nil {
+		return "", err
+	}
+	response := &gitHubReleaseResponse{}
+	err = github.call(executor.ctx, http.MethodPost, github.apiURL()+"/releases", "application/json", bytes.NewReader(request), response)
+	if err != nil {
+		return "", fmt.Errorf("cannot create GitHub release %s: %w", release.tag, err)
+	}
+	return response.HTMLURL, github.uploadAssets(executor, response, assets)
+}
+
+func (github *GitHub) call(ctx context.Context, method string, requestURL string, contentType string, body io.Reader, result interface{}) error {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("Authorization", "Bearer "+os.Getenv("GITHUB_TOKEN"))
+	header.Set("Content-Type", contentType)
+	return callAPI(ctx, "GitHub", method, requestURL, header, body, result)
+}
+
+// Returns files matching asset patterns, relative to the project directory. Assets are usually ignored by git, so
+// ignored files are not skipped.
+func expandAssets(executor *executor, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	files, err := executor.fileSystem.ListFiles(executor.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var assets []string
+	for _, pattern := range patterns {
+		matched := false
+		for _, file := range files {
+			if matchGlob(pattern, file) {
+				assets = append(assets, file)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no release asset matches %s", pattern)
+		}
+	}
+	return assets, nil
+}
+
+// Uploads assets to the release followed by their checksums.
+func (github *GitHub) uploadAssets(executor *executor, release *gitHubReleaseResponse, assets []string) error {
+	if len(assets) == 0 {
+		return nil
+	}
+	checksums := &strings.Builder{}
+	for _, asset := range assets {
+		content, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, asset))
+		if err != nil {
+			return err
+		}
+		err = github.uploadAsset(executor.ctx, release, path.Base(asset), content)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(checksums, "%x  %s\n", sha256.Sum256(content), path.Base(asset))
+	}
+	return github.uploadAsset(executor.ctx, release, DefaultChecksumsAsset, []byte(checksums.String()))
+}
+
+func (github *GitHub) uploadAsset(ctx context.Context, release *gitHubReleaseResponse, name string, content []byte) error {
+	// Upload URL is a hypermedia template like https://uploads.github.com/repos/org/app/releases/1/assets{?name,label}.
+	uploadURL := release.UploadURL
+	if braceIndex := strings.Index(uploadURL, "{"); braceIndex >= 0 {
+		uploadURL = uploadURL[:braceIndex]
+	}
+	err := github.call(ctx, http.MethodPost, uploadURL+"?name="+url.QueryEscape(name), "application/octet-stream", bytes.NewReader(content), &struct{}{})
+	if err != nil {
+		return fmt.Errorf("cannot upload release asset %s: %w", name, err)
+	}
+	return nil
+}
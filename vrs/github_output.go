@@ -0,0 +1,62 @@
+package vrs
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Returns outputs of the bump consumed by GitHub Actions workflows: version, tag and previous-version.
+func (result *BumpResult) GitHubOutputs() map[string]string {
+	return map[string]string{
+		"version":          result.NewVersion,
+		"tag":              result.Tag,
+		"previous-version": result.OldVersion,
+	}
+}
+
+// Appends outputs to the files of GitHub Actions step outputs and environment variables, pointed by GITHUB_OUTPUT and
+// GITHUB_ENV environment variables. Environment variables are named like the outputs in upper case, for example
+// PREVIOUS_VERSION. Fails outside of GitHub Actions, where none of the files is available.
+func WriteGitHubOutputs(outputs map[string]string) error {
+	outputFile, envFile := os.Getenv("GITHUB_OUTPUT"), os.Getenv("GITHUB_ENV")
+	if outputFile == "" && envFile == "" {
+		return fmt.Errorf("GITHUB_OUTPUT or GITHUB_ENV environment variable is required to write GitHub Actions outputs")
+	}
+
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if outputFile != "" {
+		err := appendGitHubFile(outputFile, names, outputs, func(name string) string { return name })
+		if err != nil {
+			return err
+		}
+	}
+	if envFile != "" {
+		return appendGitHubFile(envFile, names, outputs, func(name string) string {
+			return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		})
+	}
+	return nil
+}
+
+func appendGitHubFile(file string, names []string, values map[string]string, key func(string) string) error {
+	content := &strings.Builder{}
+	for _, name := range names {
+		_, _ = fmt.Fprintf(content, "%s=%s\n", key(name), values[name])
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open GitHub Actions file %s: %w", file, err)
+	}
+	_, err = f.WriteString(content.String())
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot write GitHub Actions file %s: %w", file, err)
+	}
+	return f.Close()
+}
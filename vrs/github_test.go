@@ -0,0 +1,154 @@
+package vrs_test
+
+import (
+	"encoding/json"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestCreateGitHubRelease(t *testing.T) {
+	// Given
+	var request map[string]interface{}
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/org/app/git/ref/tags/v1.1.0" {
+			_, _ = writer.Write([]byte(`{"ref": "refs/tags/v1.1.0"}`))
+			return
+		}
+		assert.Equal(t, "/repos/org/app/releases", r.URL.Path)
+		authorization = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		writer.WriteHeader(http.StatusCreated)
+		_, _ = writer.Write([]byte(`{"id": 1, "html_url": "https://github.com/org/app/releases/tag/v1.1.0"}`))
+	}))
+	defer server.Close()
+	setenv(t, "GITHUB_TOKEN", "secret")
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", GitHub: &vrs.GitHub{Release: true, Repository: "org/app", APIURL: server.URL}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/app/releases/tag/v1.1.0", result.ReleaseURL)
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, "v1.1.0", request["tag_name"])
+	assert.Equal(t, false, request["prerelease"])
+}
+
+func TestFailGitHubReleaseWithoutToken(t *testing.T) {
+	// Given
+	setenv(t, "GITHUB_TOKEN", "")
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", GitHub: &vrs.GitHub{Release: true, Repository: "org/app"}}).Write(basedir)
+	assert.NoError(t, err)
+	client := &recordingGitClient{}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: client}})
+
+	// Then
+	assert.EqualError(t, err, "GITHUB_TOKEN environment variable is required to create GitHub release")
+	assert.Empty(t, client.calls)
+}
+
+func TestUploadGitHubReleaseAssets(t *testing.T) {
+	// Given
+	uploads := map[string]string{}
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/org/app/git/ref/tags/v1.1.0" {
+			_, _ = writer.Write([]byte(`{"ref": "refs/tags/v1.1.0"}`))
+			return
+		}
+		if r.URL.Path == "/repos/org/app/releases" {
+			writer.WriteHeader(http.StatusCreated)
+			_, _ = writer.Write([]byte(`{"id": 1, "upload_url": "` + serverURL + `/upload{?name,label}"}`))
+			return
+		}
+		content, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		uploads[r.URL.Query().Get("name")] = string(content)
+		writer.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	setenv(t, "GITHUB_TOKEN", "secret")
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", GitHub: &vrs.GitHub{Release: true, Repository: "org/app", APIURL: server.URL, Assets: []string{"dist/*.tar.gz"}}}).Write(basedir)
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(path.Join(basedir, "dist"), 0755))
+	assert.NoError(t, ioutil.WriteFile(path.Join(basedir, "dist", "app.tar.gz"), []byte("app"), 0644))
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"app.tar.gz":    "app",
+		"checksums.txt": "a172cedcae47474b615c54d510a5d84a8dea3032e958587430b413538be3f333  app.tar.gz\n",
+	}, uploads)
+}
+
+func TestFailGitHubReleaseOfTagMissingInRepository(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	setenv(t, "GITHUB_TOKEN", "secret")
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", GitHub: &vrs.GitHub{Release: true, Repository: "org/app", APIURL: server.URL}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{}}})
+
+	// Then
+	assert.EqualError(t, err, "tag v1.1.0 not found in GitHub repository")
+}
+
+func TestWriteGitHubOutputs(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	outputFile, envFile := path.Join(dir, "output"), path.Join(dir, "env")
+	assert.NoError(t, ioutil.WriteFile(outputFile, []byte("previous=step\n"), 0644))
+	setenv(t, "GITHUB_OUTPUT", outputFile)
+	setenv(t, "GITHUB_ENV", envFile)
+	result := &vrs.BumpResult{OldVersion: "1.0.0", NewVersion: "1.1.0", Tag: "v1.1.0"}
+
+	// When
+	err := vrs.WriteGitHubOutputs(result.GitHubOutputs())
+
+	// Then
+	assert.NoError(t, err)
+	output, err := ioutil.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous=step\nprevious-version=1.0.0\ntag=v1.1.0\nversion=1.1.0\n", string(output))
+	env, err := ioutil.ReadFile(envFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "PREVIOUS_VERSION=1.0.0\nTAG=v1.1.0\nVERSION=1.1.0\n", string(env))
+}
+
+func TestFailToWriteGitHubOutputsOutsideOfGitHubActions(t *testing.T) {
+	// Given
+	setenv(t, "GITHUB_OUTPUT", "")
+	setenv(t, "GITHUB_ENV", "")
+
+	// When
+	err := vrs.WriteGitHubOutputs(map[string]string{"version": "1.1.0"})
+
+	// Then
+	assert.EqualError(t, err, "GITHUB_OUTPUT or GITHUB_ENV environment variable is required to write GitHub Actions outputs")
+}
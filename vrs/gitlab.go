@@ -0,0 +1,103 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const DefaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+// Settings of GitLab release created after the release commit is pushed. API token is read from GITLAB_TOKEN
+// environment variable. In GitLab CI pipelines CI_JOB_TOKEN is used if GITLAB_TOKEN is not set.
+type GitLab struct {
+	// Creates GitLab release of the tag, using release notes as its description.
+	Release bool `yaml:",omitempty"`
+	// ID or path (group/app) of the project. Defaults to CI_PROJECT_ID environment variable set by GitLab CI.
+	Project string `yaml:",omitempty"`
+	// URL of GitLab API. Defaults to CI_API_V4_URL environment variable set by GitLab CI or DefaultGitLabAPIURL.
+	APIURL string `yaml:"apiUrl,omitempty"`
+	// Creates the release tag with GitLab API instead of pushing it with git. Useful in pipelines allowed to create
+	// releases, but not to push tags.
+	TagViaAPI bool `yaml:"tagViaApi,omitempty"`
+}
+
+type gitLabRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Commit the tag is created for, if the tag doesn't exist yet.
+	Ref        string `json:"ref,omitempty"`
+	TagMessage string `json:"tag_message,omitempty"`
+}
+
+type gitLabReleaseResponse struct {
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+func (gitlab *GitLab) name() string {
+	return "GitLab"
+}
+
+func (gitlab *GitLab) check(executor *executor) error {
+	if gitlab.project() == "" {
+		return fmt.Errorf("GitLab project is not configured: set gitlab.project in %s or CI_PROJECT_ID environment variable", VrsConfigFileName)
+	}
+	if os.Getenv("GITLAB_TOKEN") == "" && os.Getenv("CI_JOB_TOKEN") == "" {
+		return fmt.Errorf("GITLAB_TOKEN or CI_JOB_TOKEN environment variable is required to create GitLab release")
+	}
+	return nil
+}
+
+func (gitlab *GitLab) project() string {
+	return firstNonEmpty(gitlab.Project, os.Getenv("CI_PROJECT_ID"))
+}
+
+func (gitlab *GitLab) pushesTags() bool {
+	return !gitlab.TagViaAPI
+}
+
+func (gitlab *GitLab) projectURL() string {
+	apiURL := strings.TrimSuffix(firstNonEmpty(gitlab.APIURL, os.Getenv("CI_API_V4_URL"), DefaultGitLabAPIURL), "/")
+	return apiURL + "/projects/" + url.PathEscape(gitlab.project())
+}
+
+func (gitlab *GitLab) tagExists(ctx context.Context, tag string) (bool, error) {
+	return forgeResourceExists(callAPI(ctx, "GitLab", http.MethodGet, gitlab.projectURL()+"/repository/tags/"+url.PathEscape(tag), gitlab.header(), nil, nil))
+}
+
+func (gitlab *GitLab) header() http.Header {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		header.Set("PRIVATE-TOKEN", token)
+	} else {
+		header.Set("JOB-TOKEN", os.Getenv("CI_JOB_TOKEN"))
+	}
+	return header
+}
+
+// Creates GitLab release of the tag. If the tag is created with the API, it is created for the released commit.
+func (gitlab *GitLab) createRelease(executor *executor, release *forgeRelease) (string, error) {
+	request := &gitLabRelease{TagName: release.tag, Name: release.tag, Description: release.notes}
+	if gitlab.TagViaAPI {
+		request.Ref, request.TagMessage = release.commit, release.tagMessage
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	response := &gitLabReleaseResponse{}
+	err = callAPI(executor.ctx, "GitLab", http.MethodPost, gitlab.projectURL()+"/releases", gitlab.header(), bytes.NewReader(body), response)
+	if err != nil {
+		return "", fmt.Errorf("cannot create GitLab release %s: %w", release.tag, err)
+	}
+	return response.Links.Self, nil
+}
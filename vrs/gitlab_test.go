@@ -0,0 +1,43 @@
+package vrs_test
+
+import (
+	"encoding/json"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateGitLabReleaseWithJobToken(t *testing.T) {
+	// Given
+	var request map[string]interface{}
+	var jobToken string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/group%2Fapp/releases", r.URL.EscapedPath())
+		jobToken = r.Header.Get("JOB-TOKEN")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		writer.WriteHeader(http.StatusCreated)
+		_, _ = writer.Write([]byte(`{"_links": {"self": "https://gitlab.com/group/app/-/releases/v1.1.0"}}`))
+	}))
+	defer server.Close()
+	setenv(t, "GITLAB_TOKEN", "")
+	setenv(t, "CI_JOB_TOKEN", "job-secret")
+	setenv(t, "CI_API_V4_URL", server.URL)
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", GitLab: &vrs.GitLab{Release: true, Project: "group/app", TagViaAPI: true}}).Write(basedir)
+	assert.NoError(t, err)
+	client := &recordingGitClient{}
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true, GitOptions: vrs.GitOptions{GitClient: client}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/group/app/-/releases/v1.1.0", result.ReleaseURL)
+	assert.Equal(t, "job-secret", jobToken)
+	assert.Equal(t, "v1.1.0", request["tag_name"])
+	assert.Equal(t, []string{"add", "commit Version bump.", "tag v1.1.0", "push"}, client.calls)
+}
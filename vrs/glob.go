@@ -0,0 +1,76 @@
+package vrs
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// Matches slash separated file name against the glob pattern. Besides path.Match syntax, the ** segment matches any
+// number of directories, for example charts/**/Chart.yaml matches both charts/Chart.yaml and charts/a/b/Chart.yaml.
+func matchGlob(pattern string, name string) bool {
+	return matchGlobSegments(strings.Split(path.Clean(pattern), "/"), strings.Split(path.Clean(name), "/"))
+}
+
+func matchAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchGlobSegments(patternSegments []string, nameSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(nameSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(nameSegments); i++ {
+			if matchGlobSegments(patternSegments[1:], nameSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(nameSegments) == 0 {
+		return false
+	}
+	matched, err := path.Match(patternSegments[0], nameSegments[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchGlobSegments(patternSegments[1:], nameSegments[1:])
+}
+
+// Returns project files matching the sync file name and none of the exclude patterns, relative to the project directory.
+// Files ignored by git are skipped. Names without glob characters are returned as they are.
+func expandSyncFileName(executor *executor, name string, exclude []string) ([]string, error) {
+	if !isGlob(name) {
+		return []string{name}, nil
+	}
+	files, err := executor.fileSystem.ListFiles(executor.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, file := range files {
+		if matchGlob(name, file) && !matchAnyGlob(exclude, file) {
+			matches = append(matches, file)
+		}
+	}
+	matches, err = executor.skipIgnored(matches)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: no file matches %s", ErrSyncFileMissing, name)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
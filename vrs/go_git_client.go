@@ -0,0 +1,631 @@
+package vrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	osuser "os/user"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// GitClient reading and writing the repository in given directory with go-git, so releases don't require git binary.
+// Signing and remote operations depend on the git setup of the user (GPG and SSH keys, credential helpers), so they are
+// delegated to git binary if it is installed.
+type GoGitClient struct {
+	Dir string
+}
+
+func NewGoGitClient(dir string) *GoGitClient {
+	return &GoGitClient{Dir: dir}
+}
+
+func (client *GoGitClient) Status(ctx context.Context) ([]string, error) {
+	_, worktree, err := client.worktree(ctx, "status")
+	if err != nil {
+		return nil, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, goGitError("status", err)
+	}
+	var files []string
+	for file, fileStatus := range status {
+		if fileStatus.Worktree == git.Untracked || fileStatus.Staging == git.Untracked {
+			continue
+		}
+		if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (client *GoGitClient) Head(ctx context.Context) (string, error) {
+	repository, err := client.open(ctx, "rev-parse")
+	if err != nil {
+		return "", err
+	}
+	head, err := repository.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", goGitError("rev-parse", err)
+	}
+	return head.Hash().String(), nil
+}
+
+func (client *GoGitClient) Branch(ctx context.Context) (string, error) {
+	repository, err := client.open(ctx, "symbolic-ref")
+	if err != nil {
+		return "", err
+	}
+	head, err := repository.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", goGitError("symbolic-ref", err)
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		return "", nil
+	}
+	return head.Target().Short(), nil
+}
+
+func (client *GoGitClient) Add(ctx context.Context, files ...string) error {
+	_, worktree, err := client.worktree(ctx, "add")
+	if err != nil {
+		return err
+	}
+	paths, err := client.worktreePaths(worktree, files)
+	if err != nil {
+		return goGitError("add", err)
+	}
+	// Like git binary, ignored files are not added unless they are tracked already.
+	ignored, err := client.Ignored(ctx, files...)
+	if err != nil {
+		return err
+	}
+	if len(ignored) > 0 {
+		return goGitError("add", fmt.Errorf("paths are ignored by .gitignore: %s", strings.Join(ignored, ", ")))
+	}
+	for _, path := range paths {
+		if _, err := worktree.Add(path); err != nil {
+			return goGitError("add", err)
+		}
+	}
+	return nil
+}
+
+func (client *GoGitClient) Unstage(ctx context.Context, files ...string) error {
+	repository, worktree, err := client.worktree(ctx, "reset")
+	if err != nil {
+		return err
+	}
+	paths, err := client.worktreePaths(worktree, files)
+	if err != nil {
+		return goGitError("reset", err)
+	}
+	head, err := repository.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// Without commits there is nothing to reset to, so the files are removed from the index.
+		gitIndex, err := repository.Storer.Index()
+		if err != nil {
+			return goGitError("rm", err)
+		}
+		for _, path := range paths {
+			if _, err := gitIndex.Remove(path); err != nil && !errors.Is(err, index.ErrEntryNotFound) {
+				return goGitError("rm", err)
+			}
+		}
+		return goGitError("rm", repository.Storer.SetIndex(gitIndex))
+	}
+	if err != nil {
+		return goGitError("reset", err)
+	}
+	return goGitError("reset", worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.MixedReset, Files: paths}))
+}
+
+func (client *GoGitClient) Commit(ctx context.Context, message string, options *GitCommitOptions) error {
+	if options != nil && (options.Sign || options.SigningKey != "") {
+		return NewExecGitClient(client.Dir).Commit(ctx, message, options)
+	}
+	repository, worktree, err := client.worktree(ctx, "commit")
+	if err != nil {
+		return err
+	}
+	author, err := gitSignature(repository, "author")
+	if err != nil {
+		return goGitError("commit", err)
+	}
+	committer, err := gitSignature(repository, "committer")
+	if err != nil {
+		return goGitError("commit", err)
+	}
+	_, err = worktree.Commit(cleanupGitMessage(message), &git.CommitOptions{Author: author, Committer: committer})
+	return goGitError("commit", err)
+}
+
+func (client *GoGitClient) Reset(ctx context.Context, commit string) error {
+	repository, worktree, err := client.worktree(ctx, "reset")
+	if err != nil {
+		return err
+	}
+	if commit == "" {
+		head, err := repository.Reference(plumbing.HEAD, false)
+		if err != nil {
+			return goGitError("update-ref", err)
+		}
+		if head.Type() != plumbing.SymbolicReference {
+			return goGitError("update-ref", errors.New("HEAD is detached"))
+		}
+		return goGitError("update-ref", repository.Storer.RemoveReference(head.Target()))
+	}
+	hash, err := repository.ResolveRevision(plumbing.Revision(commit))
+	if err != nil {
+		return goGitError("reset", err)
+	}
+	return goGitError("reset", worktree.Reset(&git.ResetOptions{Commit: *hash, Mode: git.SoftReset}))
+}
+
+func (client *GoGitClient) Tag(ctx context.Context, name string, options *GitTagOptions) error {
+	if options == nil {
+		options = &GitTagOptions{}
+	}
+	if options.Sign || options.SigningKey != "" {
+		return NewExecGitClient(client.Dir).Tag(ctx, name, options)
+	}
+	repository, err := client.open(ctx, "tag")
+	if err != nil {
+		return err
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return goGitError("tag", err)
+	}
+	var tagOptions *git.CreateTagOptions
+	if options.Message != "" {
+		tagger, err := gitSignature(repository, "committer")
+		if err != nil {
+			return goGitError("tag", err)
+		}
+		tagOptions = &git.CreateTagOptions{Tagger: tagger, Message: cleanupGitMessage(options.Message)}
+	}
+	_, err = repository.CreateTag(name, head.Hash(), tagOptions)
+	return goGitError("tag", err)
+}
+
+func (client *GoGitClient) DeleteTag(ctx context.Context, name string) error {
+	repository, err := client.open(ctx, "tag")
+	if err != nil {
+		return err
+	}
+	return goGitError("tag", repository.DeleteTag(name))
+}
+
+func (client *GoGitClient) Push(ctx context.Context, remote string, branch string) error {
+	if gitInstalled() {
+		return NewExecGitClient(client.Dir).Push(ctx, remote, branch)
+	}
+	repository, err := client.open(ctx, "push")
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		branch, err = client.Branch(ctx)
+		if err != nil {
+			return err
+		}
+		if branch == "" {
+			return goGitError("push", errors.New("HEAD is detached, push requires target branch"))
+		}
+	}
+	refSpec := gitconfig.RefSpec("HEAD:" + plumbing.NewBranchReferenceName(branch).String())
+	return client.push(ctx, repository, remote, refSpec)
+}
+
+func (client *GoGitClient) PushTags(ctx context.Context, remote string) error {
+	if gitInstalled() {
+		return NewExecGitClient(client.Dir).PushTags(ctx, remote)
+	}
+	repository, err := client.open(ctx, "push")
+	if err != nil {
+		return err
+	}
+	return client.push(ctx, repository, remote, "refs/tags/*:refs/tags/*")
+}
+
+func (client *GoGitClient) RemoteTagExists(ctx context.Context, remote string, tag string) (bool, error) {
+	if gitInstalled() {
+		return NewExecGitClient(client.Dir).RemoteTagExists(ctx, remote, tag)
+	}
+	repository, err := client.open(ctx, "ls-remote")
+	if err != nil {
+		return false, err
+	}
+	gitRemote, err := client.remote(repository, remote)
+	if err != nil {
+		return false, goGitError("ls-remote", err)
+	}
+	references, err := gitRemote.ListContext(ctx, &git.ListOptions{})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return false, nil
+	}
+	if err != nil {
+		return false, goGitError("ls-remote", err)
+	}
+	for _, reference := range references {
+		if reference.Name() == plumbing.NewTagReferenceName(tag) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (client *GoGitClient) Ignored(ctx context.Context, files ...string) ([]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	repository, worktree, err := client.worktree(ctx, "check-ignore")
+	if errors.Is(err, ErrNotGitRepository) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	paths, err := client.worktreePaths(worktree, files)
+	if err != nil {
+		return nil, goGitError("check-ignore", err)
+	}
+	rootFileSystem := osfs.New("/")
+	systemPatterns, err := gitignore.LoadSystemPatterns(rootFileSystem)
+	if err != nil {
+		return nil, goGitError("check-ignore", err)
+	}
+	globalPatterns, err := gitignore.LoadGlobalPatterns(rootFileSystem)
+	if err != nil {
+		return nil, goGitError("check-ignore", err)
+	}
+	repositoryPatterns, err := gitignore.ReadPatterns(worktree.Filesystem, nil)
+	if err != nil {
+		return nil, goGitError("check-ignore", err)
+	}
+	matcher := gitignore.NewMatcher(append(append(systemPatterns, globalPatterns...), repositoryPatterns...))
+	gitIndex, err := repository.Storer.Index()
+	if err != nil {
+		return nil, goGitError("check-ignore", err)
+	}
+	var ignored []string
+	for i, path := range paths {
+		// Tracked files are never ignored.
+		if _, err := gitIndex.Entry(path); err == nil {
+			continue
+		}
+		if matcher.Match(strings.Split(path, "/"), false) {
+			ignored = append(ignored, files[i])
+		}
+	}
+	return ignored, nil
+}
+
+func (client *GoGitClient) LastTag(ctx context.Context, revision string, pattern string) (string, error) {
+	repository, err := client.open(ctx, "tag")
+	if err != nil {
+		return "", err
+	}
+	commit, err := resolveCommit(repository, revision)
+	if err != nil || commit == nil {
+		return "", goGitError("tag", err)
+	}
+	reachable, err := reachableCommits(repository, commit)
+	if err != nil {
+		return "", goGitError("tag", err)
+	}
+	patternRegexp := globToRegexp(pattern)
+	tags, err := repository.Tags()
+	if err != nil {
+		return "", goGitError("tag", err)
+	}
+	var merged []string
+	err = tags.ForEach(func(reference *plumbing.Reference) error {
+		name := reference.Name().Short()
+		if !patternRegexp.MatchString(name) {
+			return nil
+		}
+		tagCommit, err := resolveCommit(repository, reference.Name().String())
+		if err != nil {
+			return err
+		}
+		if tagCommit != nil && reachable[tagCommit.Hash] {
+			merged = append(merged, name)
+		}
+		return nil
+	})
+	if err != nil || len(merged) == 0 {
+		return "", goGitError("tag", err)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return compareVersionRefnames(merged[i], merged[j]) > 0
+	})
+	return merged[0], nil
+}
+
+func (client *GoGitClient) Log(ctx context.Context, since string, until string) ([]*GitLogEntry, error) {
+	repository, err := client.open(ctx, "log")
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveCommit(repository, until)
+	if err != nil || commit == nil {
+		return nil, goGitError("log", err)
+	}
+	excluded := map[plumbing.Hash]bool{}
+	if since != "" {
+		sinceCommit, err := resolveCommit(repository, since)
+		if err != nil {
+			return nil, goGitError("log", err)
+		}
+		if sinceCommit == nil {
+			return nil, goGitError("log", fmt.Errorf("unknown revision %s", since))
+		}
+		excluded, err = reachableCommits(repository, sinceCommit)
+		if err != nil {
+			return nil, goGitError("log", err)
+		}
+	}
+	var entries []*GitLogEntry
+	commits := object.NewCommitIterCTime(commit, nil, nil)
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if excluded[commit.Hash] {
+			return nil
+		}
+		message := strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)
+		entry := &GitLogEntry{SHA: commit.Hash.String(), Subject: message[0]}
+		if len(message) == 2 {
+			entry.Body = strings.TrimSpace(message[1])
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, goGitError("log", err)
+	}
+	return entries, nil
+}
+
+// Opens the repository containing the directory of the client. Command names the operation in the returned errors.
+func (client *GoGitClient) open(ctx context.Context, command string) (*git.Repository, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &GitCommandError{Args: []string{command}, Err: err}
+	}
+	repository, err := git.PlainOpenWithOptions(client.Dir, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("%w: %s", ErrNotGitRepository, client.Dir)
+	}
+	if err != nil {
+		return nil, goGitError(command, err)
+	}
+	return repository, nil
+}
+
+func (client *GoGitClient) worktree(ctx context.Context, command string) (*git.Repository, *git.Worktree, error) {
+	repository, err := client.open(ctx, command)
+	if err != nil {
+		return nil, nil, err
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, nil, goGitError(command, err)
+	}
+	return repository, worktree, nil
+}
+
+// Returns paths of given files, relative to the directory of the client, as paths relative to the root of the working
+// tree used by the index.
+func (client *GoGitClient) worktreePaths(worktree *git.Worktree, files []string) ([]string, error) {
+	dir, err := filepath.Abs(client.Dir)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		path, err := filepath.Rel(worktree.Filesystem.Root(), file)
+		if err != nil {
+			return nil, err
+		}
+		if path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%s is outside of the repository", file)
+		}
+		paths[i] = filepath.ToSlash(path)
+	}
+	return paths, nil
+}
+
+func (client *GoGitClient) push(ctx context.Context, repository *git.Repository, remote string, refSpec gitconfig.RefSpec) error {
+	gitRemote, err := client.remote(repository, remote)
+	if err != nil {
+		return goGitError("push", err)
+	}
+	err = gitRemote.PushContext(ctx, &git.PushOptions{RemoteName: gitRemote.Config().Name, RefSpecs: []gitconfig.RefSpec{refSpec}})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return goGitError("push", err)
+}
+
+// Returns remote of given name or URL. Empty remote means the remote used by git push without arguments.
+func (client *GoGitClient) remote(repository *git.Repository, remote string) (*git.Remote, error) {
+	if remote == "" {
+		var err error
+		remote, err = goGitPushRemote(repository)
+		if err != nil {
+			return nil, err
+		}
+	}
+	gitRemote, err := repository.Remote(remote)
+	if errors.Is(err, git.ErrRemoteNotFound) && strings.ContainsAny(remote, ":/") {
+		return git.NewRemote(repository.Storer, &gitconfig.RemoteConfig{Name: "anonymous", URLs: []string{remote}}), nil
+	}
+	return gitRemote, err
+}
+
+// Returns remote used by git push without arguments: push remote of the current branch, default push remote, remote of
+// the current branch or origin.
+func goGitPushRemote(repository *git.Repository) (string, error) {
+	config, err := repository.Config()
+	if err != nil {
+		return "", err
+	}
+	pushDefault := config.Raw.Section("remote").Option("pushDefault")
+	head, err := repository.Reference(plumbing.HEAD, false)
+	if err != nil || head.Type() != plumbing.SymbolicReference {
+		return firstNonEmpty(pushDefault, "origin"), nil
+	}
+	branch := config.Raw.Section("branch").Subsection(head.Target().Short())
+	return firstNonEmpty(branch.Option("pushRemote"), pushDefault, branch.Option("remote"), "origin"), nil
+}
+
+// Wraps go-git error the same way as the failures of git binary, so callers handle both clients alike.
+func goGitError(command string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var commandErr *GitCommandError
+	if errors.As(err, &commandErr) || errors.Is(err, ErrNotGitRepository) || errors.Is(err, ErrGitNotInstalled) {
+		return err
+	}
+	return &GitCommandError{Args: []string{command}, Stderr: err.Error(), Err: err}
+}
+
+func gitInstalled() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// Returns author or committer of new commits and tags read from environment variables and git config, like git binary.
+func gitSignature(repository *git.Repository, role string) (*object.Signature, error) {
+	config, err := repository.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		return nil, err
+	}
+	user := config.Author
+	if role == "committer" {
+		user = config.Committer
+	}
+	prefix := "GIT_" + strings.ToUpper(role) + "_"
+	name := firstNonEmpty(os.Getenv(prefix+"NAME"), user.Name, config.User.Name)
+	email := firstNonEmpty(os.Getenv(prefix+"EMAIL"), user.Email, config.User.Email, os.Getenv("EMAIL"))
+	// Without configured identity git binary falls back to the system user.
+	if systemUser, err := osuser.Current(); err == nil && (name == "" || email == "") {
+		name = firstNonEmpty(name, systemUser.Name, systemUser.Username)
+		if hostname, err := os.Hostname(); err == nil && email == "" && hostname != "" {
+			email = systemUser.Username + "@" + hostname
+		}
+	}
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("%s identity unknown, configure user.name and user.email with git config", role)
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
+
+// Removes trailing whitespace of the lines and surrounding blank lines of the message, like git binary does with the
+// messages given on the command line.
+func cleanupGitMessage(message string) string {
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// Returns commit the revision points to. Empty revision means HEAD. Nil is returned if the revision doesn't exist.
+func resolveCommit(repository *git.Repository, revision string) (*object.Commit, error) {
+	if revision == "" {
+		revision = plumbing.HEAD.String()
+	}
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) || errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repository.CommitObject(*hash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, nil
+	}
+	return commit, err
+}
+
+// Returns hashes of the commit and all its ancestors.
+func reachableCommits(repository *git.Repository, commit *object.Commit) (map[plumbing.Hash]bool, error) {
+	reachable := map[plumbing.Hash]bool{}
+	err := object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(commit *object.Commit) error {
+		reachable[commit.Hash] = true
+		return nil
+	})
+	return reachable, err
+}
+
+// Converts glob pattern of git tag --list to regular expression. Unlike file globs, * matches slashes too.
+func globToRegexp(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// Compares tag names like git --sort=v:refname: numbers embedded in the names are compared by their value, the rest of
+// the names by their bytes.
+func compareVersionRefnames(a string, b string) int {
+	for a != "" && b != "" {
+		aDigits, bDigits := isDigit(a[0]), isDigit(b[0])
+		if aDigits != bDigits {
+			return strings.Compare(a, b)
+		}
+		aChunk, bChunk := leadingChunk(a, aDigits), leadingChunk(b, bDigits)
+		if aDigits {
+			aNumber, bNumber := strings.TrimLeft(aChunk, "0"), strings.TrimLeft(bChunk, "0")
+			if len(aNumber) != len(bNumber) {
+				return len(aNumber) - len(bNumber)
+			}
+			if comparison := strings.Compare(aNumber, bNumber); comparison != 0 {
+				return comparison
+			}
+		} else if comparison := strings.Compare(aChunk, bChunk); comparison != 0 {
+			return comparison
+		}
+		a, b = a[len(aChunk):], b[len(bChunk):]
+	}
+	return len(a) - len(b)
+}
+
+// Returns the longest prefix of the string consisting of digits only or of non-digits only.
+func leadingChunk(s string, digits bool) string {
+	end := 0
+	for end < len(s) && isDigit(s[end]) == digits {
+		end++
+	}
+	return s[:end]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,175 @@
+package vrs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const DefaultGoVersionFile = "version/version.go"
+
+var goVersionFileTemplate = template.Must(template.New("go version file").Parse(`// Code generated by vrs. DO NOT EDIT.
+
+package {{.Package}}
+
+// Variables can be overridden with -ldflags "-X {{.ImportPath}}.Version=..." during the build.
+var (
+	Version = {{printf "%q" .Version}}
+	Commit  = {{printf "%q" .Commit}}
+	Date    = {{printf "%q" .Date}}
+)
+`))
+
+type GoVersionOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Path of the generated file relative to the project directory. Defaults to DefaultGoVersionFile.
+	Output string
+	// Date embedded into the binary. Zero value means current time.
+	Date time.Time
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Client used to read the current commit. Defaults to GoGitClient reading the repository of the project directory.
+	GitClient GitClient
+}
+
+func NewDefaultGoVersionOptions() (*GoVersionOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &GoVersionOptions{
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		Output:         DefaultGoVersionFile,
+	}, nil
+}
+
+// Version information embedded into Go binary.
+type GoVersion struct {
+	// Import path of the package holding the version variables, for example github.com/org/app/version.
+	ImportPath string
+	Package    string
+	Version    string
+	Commit     string
+	// Build date in RFC 3339 format.
+	Date string
+}
+
+// Reads version information of Go project. Import path of the version package is derived from go.mod of the project.
+func ReadGoVersion(options *GoVersionOptions) (*GoVersion, error) {
+	return ReadGoVersionContext(context.Background(), options)
+}
+
+func ReadGoVersionContext(ctx context.Context, options *GoVersionOptions) (*GoVersion, error) {
+	if options == nil {
+		o, err := NewDefaultGoVersionOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	fileSystem := fileSystemOrDefault(options.FileSystem)
+	config, err := parseConfigFile(fileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := fileSystem.ReadFile(path.Join(options.Basedir, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read Go module: %w", err)
+	}
+	module := ""
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "module" {
+			module = strings.Trim(fields[1], `"`)
+		}
+	}
+	if module == "" {
+		return nil, fmt.Errorf("go.mod of %s has no module directive", options.Basedir)
+	}
+
+	git := options.GitClient
+	if git == nil {
+		git = NewGoGitClient(options.Basedir)
+	}
+	commit, err := git.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	date := options.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	packageDir := path.Dir(firstNonEmpty(options.Output, DefaultGoVersionFile))
+	return &GoVersion{
+		ImportPath: path.Join(module, packageDir),
+		Package:    path.Base(path.Join(module, packageDir)),
+		Version:    config.Version,
+		Commit:     commit,
+		Date:       date.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// Returns -ldflags value setting version variables of the generated version package, for example
+// -X github.com/org/app/version.Version=1.2.0 -X ...
+func (version *GoVersion) Ldflags() string {
+	var flags []string
+	for _, variable := range []struct{ name, value string }{{"Version", version.Version}, {"Commit", version.Commit}, {"Date", version.Date}} {
+		flags = append(flags, fmt.Sprintf("-X %s.%s=%s", version.ImportPath, variable.name, variable.value))
+	}
+	return strings.Join(flags, " ")
+}
+
+// Generates Go source file of the version package.
+func (version *GoVersion) Source() ([]byte, error) {
+	source := &bytes.Buffer{}
+	err := goVersionFileTemplate.Execute(source, version)
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(source.Bytes())
+}
+
+// Writes Go source file with the version information of the project, so Go binaries report the version managed by
+// vrs.
+func GenerateGoVersion(options *GoVersionOptions) (*GoVersion, error) {
+	return GenerateGoVersionContext(context.Background(), options)
+}
+
+func GenerateGoVersionContext(ctx context.Context, options *GoVersionOptions) (*GoVersion, error) {
+	if options == nil {
+		o, err := NewDefaultGoVersionOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	version, err := ReadGoVersionContext(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	source, err := version.Source()
+	if err != nil {
+		return nil, err
+	}
+	output := path.Join(options.Basedir, firstNonEmpty(options.Output, DefaultGoVersionFile))
+	fileSystem := fileSystemOrDefault(options.FileSystem)
+	if _, ok := fileSystem.(*OSFileSystem); ok {
+		err = os.MkdirAll(path.Dir(output), 0755)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return version, fileSystem.WriteFile(output, source, DefaultFileMode)
+}
@@ -0,0 +1,29 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGenerateGoVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.2.0\n"),
+		"go.mod":  []byte("module github.com/org/app\n\ngo 1.16\n"),
+	})
+	options := &vrs.GoVersionOptions{Basedir: ".", Date: time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC), FileSystem: fileSystem, GitClient: &recordingGitClient{}}
+
+	// When
+	version, err := vrs.GenerateGoVersion(options)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "-X github.com/org/app/version.Version=1.2.0 -X github.com/org/app/version.Commit= -X github.com/org/app/version.Date=2024-06-15T10:00:00Z", version.Ldflags())
+	source, err := fileSystem.ReadFile("version/version.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "package version\n")
+	assert.Contains(t, string(source), "\tVersion = \"1.2.0\"\n")
+	assert.Contains(t, string(source), "\tDate    = \"2024-06-15T10:00:00Z\"\n")
+}
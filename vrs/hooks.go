@@ -0,0 +1,53 @@
+package vrs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Shell commands executed around the bump, for example to build, test or publish the project. Commands are executed
+// by sh in the project directory, with the variables of the bump (OLD_VERSION, NEW_VERSION, MAJOR, MINOR, PATCH,
+// PRERELEASE, DATE, COMMIT_SHA and comma separated PROFILES) in the environment. Failed command aborts the bump and
+// changes made so far are rolled back.
+type Hooks struct {
+	// Executed before the new version is written.
+	PreBump []string `yaml:"preBump,omitempty"`
+	// Executed after the new version is written and synchronized, before the release commit.
+	PostBump []string `yaml:"postBump,omitempty"`
+	// Executed after the release tag is created, before the push.
+	PostTag []string `yaml:"postTag,omitempty"`
+}
+
+func (executor *executor) runHooks(stage string, commands []string) error {
+	for _, command := range commands {
+		if err := executor.ctx.Err(); err != nil {
+			return err
+		}
+		if executor.dryRun {
+			_, err := fmt.Fprintf(executor.output, "Would execute %s hook: %s\n", stage, command)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		cmd := exec.CommandContext(executor.ctx, "sh", "-c", command)
+		cmd.Dir = executor.baseDir
+		cmd.Env = append(os.Environ(), executor.templateData(executor.bump.newVersion).environment()...)
+		cmd.Stdout = executor.output
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("%s hook %q failed: %w", stage, command, err)
+		}
+	}
+	return nil
+}
+
+func (config *VrsConfig) hooks() *Hooks {
+	if config.Hooks == nil {
+		return &Hooks{}
+	}
+	return config.Hooks
+}
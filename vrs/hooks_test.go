@@ -0,0 +1,80 @@
+package vrs_test
+
+import (
+	"bytes"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func TestRunHooks(t *testing.T) {
+	// Given
+	logFile := path.Join(t.TempDir(), "hooks.log")
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	err := (&vrs.VrsConfig{Version: "1.0.0", Hooks: &vrs.Hooks{
+		PreBump:  []string{"echo pre $OLD_VERSION $NEW_VERSION $(grep ^version vrs.yml) >> " + logFile},
+		PostBump: []string{"echo post $(grep ^version vrs.yml) >> " + logFile},
+		PostTag:  []string{"echo tag $(git tag --points-at HEAD) >> " + logFile},
+	}}).Write(basedir)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "commit", "-am", "Add hooks.")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	log, err := ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "pre 1.0.0 1.1.0 version: 1.0.0\npost version: 1.1.0\ntag v1.1.0\n", string(log))
+}
+
+func TestExportBumpVariablesToHooks(t *testing.T) {
+	// Given
+	logFile := path.Join(t.TempDir(), "hooks.log")
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	err := (&vrs.VrsConfig{Version: "1.0.0", Hooks: &vrs.Hooks{
+		PreBump: []string{"echo $MAJOR $MINOR $PATCH $PRERELEASE $PROFILES $COMMIT_SHA > " + logFile},
+	}}).Write(basedir)
+	assert.NoError(t, err)
+	gitOutput(t, basedir, "commit", "-am", "Add hooks.")
+	head := gitOutput(t, basedir, "rev-parse", "HEAD")
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Segment: vrs.PrereleaseSegment, ActiveProfiles: []string{"docker", "helm"}})
+
+	// Then
+	assert.NoError(t, err)
+	log, err := ioutil.ReadFile(logFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "1 0 1 rc.1 docker,helm "+head, string(log))
+}
+
+func TestRollBackFailedHook(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Hooks: &vrs.Hooks{PostBump: []string{"exit 3"}}})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.EqualError(t, err, `postBump hook "exit 3" failed: exit status 3`)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestReportHooksInDryRun(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", Hooks: &vrs.Hooks{PreBump: []string{"make test"}}})
+	output := &bytes.Buffer{}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, DryRun: true, DryRunOutput: output})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "Would execute preBump hook: make test\n")
+}
@@ -0,0 +1,157 @@
+package vrs
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path"
+	"strconv"
+)
+
+// Version of the config format read and written by this version of vrs. Configs without configVersion have version 1.
+const CurrentConfigVersion = 1
+
+// Migrations of config documents, keyed by the version they migrate from to the next one. Migration renames or
+// restructures the keys of the document changed by the next version of the config format.
+var configMigrations = map[int]func(document *yaml.Node) error{}
+
+// Returns config file migrated to CurrentConfigVersion. Config is returned unchanged if it has the current version
+// already.
+func migrateConfig(yml []byte) ([]byte, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(yml, document)
+	if err != nil || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		// Let parsing of the config report the problem.
+		return yml, nil
+	}
+	root := document.Content[0]
+	version, err := configVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentConfigVersion {
+		return nil, fmt.Errorf("config version %d is newer than version %d supported by this version of vrs", version, CurrentConfigVersion)
+	}
+	if version == CurrentConfigVersion {
+		return yml, nil
+	}
+
+	for ; version < CurrentConfigVersion; version++ {
+		err = configMigrations[version](document)
+		if err != nil {
+			return nil, fmt.Errorf("cannot migrate config from version %d: %w", version, err)
+		}
+	}
+	setMappingValue(root, "configVersion", strconv.Itoa(CurrentConfigVersion))
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	err = encoder.Encode(document)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), encoder.Close()
+}
+
+func configVersion(root *yaml.Node) (int, error) {
+	value := mappingValue(root, "configVersion")
+	if value == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(value.Value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid config version %q", value.Value)
+	}
+	return version, nil
+}
+
+func setMappingValue(node *yaml.Node, key string, value string) {
+	existing := mappingValue(node, key)
+	if existing != nil {
+		existing.Value = value
+		return
+	}
+	node.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: value},
+	}, node.Content...)
+}
+
+type MigrateConfigOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+}
+
+func NewDefaultMigrateConfigOptions() (*MigrateConfigOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &MigrateConfigOptions{Basedir: basedir}, nil
+}
+
+// Rewrites config file of the project in CurrentConfigVersion format, declaring its version explicitly. Returns false
+// if the file already declares the current version.
+func MigrateConfig(options *MigrateConfigOptions) (bool, error) {
+	if options == nil {
+		o, err := NewDefaultMigrateConfigOptions()
+		if err != nil {
+			return false, err
+		}
+		options = o
+	}
+	fileSystem := fileSystemOrDefault(options.FileSystem)
+	file, yml, err := readProjectConfigFile(fileSystem, options.Basedir, options.ConfigPath)
+	if err != nil {
+		return false, err
+	}
+	document := &yaml.Node{}
+	err = yaml.Unmarshal(yml, document)
+	if err != nil {
+		return false, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return false, fmt.Errorf("config file %s is not a YAML mapping", file)
+	}
+	if mappingValue(document.Content[0], "configVersion") == nil && CurrentConfigVersion == 1 {
+		// Config without version is in the current format already. The version is declared keeping its layout.
+		return true, writeConfigFile(fileSystem, file, append([]byte("configVersion: 1\n"), yml...))
+	}
+	migrated, err := migrateConfig(yml)
+	if err != nil || bytes.Equal(migrated, yml) {
+		return false, err
+	}
+	return true, writeConfigFile(fileSystem, file, migrated)
+}
+
+func writeConfigFile(fileSystem FileSystem, file string, content []byte) error {
+	mode, err := fileSystem.Mode(file)
+	if err != nil {
+		return err
+	}
+	return fileSystem.WriteFile(file, content, mode)
+}
+
+// Reads config file of the project, returning its path and content.
+func readProjectConfigFile(fileSystem FileSystem, basedir string, configPath string) (string, []byte, error) {
+	configPath, err := relativeConfigPath(basedir, configPath)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, candidate := range configCandidates(configPath) {
+		file := path.Join(basedir, candidate)
+		yml, err := fileSystem.ReadFile(file)
+		if err == nil {
+			return file, yml, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", nil, err
+		}
+	}
+	return "", nil, NoVersioonFileFound
+}
@@ -0,0 +1,35 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMigrateUnversionedConfig(t *testing.T) {
+	// Given
+	config := "# Release settings\nversion: 1.0.0\nsync:\n  files:\n  - name: VERSION\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config)})
+
+	// When
+	migrated, err := vrs.MigrateConfig(&vrs.MigrateConfigOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.True(t, migrated)
+	assert.Equal(t, "configVersion: 1\n"+config, readInMemory(t, fileSystem, "vrs.yml"))
+	migrated, err = vrs.MigrateConfig(&vrs.MigrateConfigOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func TestFailOnConfigVersionNewerThanSupported(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("configVersion: 99\nversion: 1.0.0\n")})
+
+	// When
+	_, err := vrs.ParseVersioonConfigFS(fileSystem, ".")
+
+	// Then
+	assert.EqualError(t, err, "cannot read config vrs.yml: config version 99 is newer than version 1 supported by this version of vrs")
+}
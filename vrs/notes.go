@@ -0,0 +1,155 @@
+package vrs
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+const DefaultNotesTemplate = "{{range .Commits}}- {{.Subject}}\n{{end}}"
+
+// Settings of the release notes rendered by Notes.
+type Notes struct {
+	// Go template of the release notes. Template data are described by ReleaseNotes.
+	Template string `yaml:",omitempty"`
+}
+
+type NotesOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Go template of the release notes overriding the template from vrs.yml. Defaults to DefaultNotesTemplate.
+	Template string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	GitOptions
+}
+
+func NewDefaultNotesOptions() (*NotesOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &NotesOptions{Basedir: basedir, UserConfigFile: defaultUserConfigFile()}, nil
+}
+
+// Data of the release notes template.
+type ReleaseNotes struct {
+	Version string
+	// Tag of the version. The tag doesn't exist yet if the notes are rendered before the release.
+	Tag string
+	// Tag of the previous release. Empty for the first release.
+	PreviousTag string
+	// Release date in YYYY-MM-DD format.
+	Date    string
+	Commits []*NotesCommit
+}
+
+// Commit listed in the release notes.
+type NotesCommit struct {
+	SHA      string
+	ShortSHA string
+	Subject  string
+	Body     string
+	// Commit message parsed according to Conventional Commits. Nil if the message doesn't follow the convention.
+	Conventional *ConventionalCommit
+	// Numbers of pull requests referenced by the commit message like #123, without the hash sign.
+	PullRequests []string
+}
+
+var pullRequestReference = regexp.MustCompile(`#(\d+)\b`)
+
+// Renders release notes of the current version from the commits made since the previous release tag. If the current
+// version has been tagged already, commits between the previous tag and the tag of the current version are listed.
+func RenderNotes(options *NotesOptions) (string, error) {
+	return RenderNotesContext(context.Background(), options)
+}
+
+func RenderNotesContext(ctx context.Context, options *NotesOptions) (string, error) {
+	if options == nil {
+		o, err := NewDefaultNotesOptions()
+		if err != nil {
+			return "", err
+		}
+		options = o
+	}
+
+	config, err := parseConfigFile(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile)
+	if err != nil {
+		return "", err
+	}
+	executor := newExecutor(ctx, options.Basedir, false, nil, options.GitClient, options.FileSystem, nil)
+	return config.renderNotes(executor, &options.GitOptions, options.Template)
+}
+
+// Renders release notes of the current version. Empty template means the template from vrs.yml or the default one.
+func (config *VrsConfig) renderNotes(executor *executor, gitOptions *GitOptions, notesTemplate string) (string, error) {
+	notes, err := config.releaseNotes(executor, gitOptions)
+	if err != nil {
+		return "", err
+	}
+	if notesTemplate == "" && config.Notes != nil {
+		notesTemplate = config.Notes.Template
+	}
+	return renderTemplate("release notes", firstNonEmpty(notesTemplate, DefaultNotesTemplate), notes)
+}
+
+// Collects commits of the release notes of the current version.
+func (config *VrsConfig) releaseNotes(executor *executor, gitOptions *GitOptions) (*ReleaseNotes, error) {
+	tag, previousTag, entries, err := config.versionCommits(executor, gitOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := &ReleaseNotes{Version: config.tagVersion(), Tag: tag, PreviousTag: previousTag, Date: time.Now().Format("2006-01-02")}
+	for _, entry := range entries {
+		commit := &NotesCommit{SHA: entry.SHA, ShortSHA: entry.SHA, Subject: entry.Subject, Body: entry.Body, PullRequests: []string{}}
+		if len(commit.ShortSHA) > 7 {
+			commit.ShortSHA = commit.ShortSHA[:7]
+		}
+		commit.Conventional, _ = ParseConventionalCommit(entry.Subject, entry.Body)
+		referenced := map[string]bool{}
+		for _, match := range pullRequestReference.FindAllStringSubmatch(entry.Subject+"\n"+entry.Body, -1) {
+			if !referenced[match[1]] {
+				referenced[match[1]] = true
+				commit.PullRequests = append(commit.PullRequests, match[1])
+			}
+		}
+		notes.Commits = append(notes.Commits, commit)
+	}
+	return notes, nil
+}
+
+// Returns tag of the current version, the previous release tag and commits made between them. If the current version
+// hasn't been tagged yet, commits made since the last release tag are returned.
+func (config *VrsConfig) versionCommits(executor *executor, gitOptions *GitOptions) (string, string, []*GitLogEntry, error) {
+	tag, err := config.tagName(executor, gitOptions)
+	if err != nil {
+		return "", "", nil, err
+	}
+	pattern, err := config.tagPattern(executor, gitOptions)
+	if err != nil {
+		return "", "", nil, err
+	}
+	until := ""
+	previousTag, err := executor.git.LastTag(executor.ctx, "", pattern)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if previousTag == tag {
+		until = tag
+		previousTag, err = executor.git.LastTag(executor.ctx, tag+"^", pattern)
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+	entries, err := executor.git.Log(executor.ctx, previousTag, until)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return tag, previousTag, entries, nil
+}
@@ -0,0 +1,83 @@
+package vrs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultNotificationTemplate = "Released {{.Tag}}.{{with .URL}}\n{{.}}{{end}}{{with .Changelog}}\n\n{{.}}{{end}}"
+
+// Notification about the release posted to the incoming webhook of Slack, Microsoft Teams or compatible service after
+// the release is pushed.
+type Notify struct {
+	// URL of the incoming webhook.
+	Webhook string `yaml:",omitempty"`
+	// Go template of the message. Available variables are Version, Tag, URL (page of the release created in git hosting
+	// service) and Changelog (changes of the release grouped like in the changelog).
+	Message string `yaml:",omitempty"`
+}
+
+type notificationTemplateData struct {
+	Version   string
+	Tag       string
+	URL       string
+	Changelog string
+}
+
+// Posts notification about the pushed release to the webhook. The release is published already, so failed
+// notification is reported as a warning instead of failing the release.
+func (config *VrsConfig) notify(executor *executor, gitOptions *GitOptions, result *BumpResult) error {
+	if config.Notify == nil || config.Notify.Webhook == "" {
+		return nil
+	}
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would post release notification to webhook.\n")
+		return err
+	}
+	err := config.postNotification(executor, gitOptions, result)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("cannot post release notification: %s", err))
+	}
+	return nil
+}
+
+func (config *VrsConfig) postNotification(executor *executor, gitOptions *GitOptions, result *BumpResult) error {
+	_, _, versionCommits, err := config.versionCommits(executor, gitOptions)
+	if err != nil {
+		return err
+	}
+	// Commits created by vrs itself are not changes of the project.
+	releaseCommits := map[string]bool{}
+	for _, sha := range result.Commits {
+		releaseCommits[sha] = true
+	}
+	var commits []*GitLogEntry
+	for _, commit := range versionCommits {
+		if !releaseCommits[commit.SHA] {
+			commits = append(commits, commit)
+		}
+	}
+	// Heading of the changelog section is left out, as the message names the release already.
+	changelog := renderChangelogSection(config.tagVersion(), time.Now(), commits)
+	changelog = strings.TrimSpace(changelog[strings.Index(changelog, "\n")+1:])
+	message, err := renderTemplate("notification", firstNonEmpty(config.Notify.Message, DefaultNotificationTemplate), &notificationTemplateData{
+		Version:   config.tagVersion(),
+		Tag:       result.Tag,
+		URL:       result.ReleaseURL,
+		Changelog: changelog,
+	})
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return callAPI(executor.ctx, "webhook", http.MethodPost, config.Notify.Webhook, header, bytes.NewReader(body), nil)
+}
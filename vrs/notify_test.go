@@ -0,0 +1,62 @@
+package vrs_test
+
+import (
+	"encoding/json"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+)
+
+func newPushedGitProject(t *testing.T, notify *vrs.Notify) string {
+	remote, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", "--bare", remote).Run()
+	assert.NoError(t, err)
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0"})
+	gitOutput(t, basedir, "remote", "add", "origin", remote)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Push: &vrs.Push{Branch: "main"}, Notify: notify}).Write(basedir)
+	assert.NoError(t, err)
+	return basedir
+}
+
+func TestPostReleaseNotification(t *testing.T) {
+	// Given
+	var message map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		_, _ = writer.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	basedir := newPushedGitProject(t, &vrs.Notify{Webhook: server.URL + "/hooks/release"})
+	commitFile(t, basedir, "parser.go", "fix(parser): handle empty input")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, map[string]string{"text": "Released v1.1.0.\n\n### Fixed\n\n- **parser:** handle empty input"}, message)
+}
+
+func TestWarnAboutFailedNotification(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, r *http.Request) {
+		writer.WriteHeader(http.StatusForbidden)
+		_, _ = writer.Write([]byte("invalid_token"))
+	}))
+	defer server.Close()
+	basedir := newPushedGitProject(t, &vrs.Notify{Webhook: server.URL, Message: "{{.Version}} is out"})
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cannot post release notification: webhook API responded with 403 Forbidden: invalid_token"}, result.Warnings)
+}
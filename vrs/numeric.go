@@ -0,0 +1,96 @@
+package vrs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const RevisionSegment = "revision"
+
+// Numeric versioning scheme of versions consisting of dot-separated numbers only, for example 1.2.3.4 used by .NET
+// assemblies and Windows installers. Segments are bumped by name (major, minor, patch and revision for the first four
+// segments) or by position starting from 1, for example 5 bumps the fifth segment. Lower segments are reset to zero.
+type Numeric struct {
+	// Number of segments of the version. Zero value accepts versions of any length.
+	Segments int
+}
+
+func (numeric *Numeric) Next(current string, kind BumpKind) (string, error) {
+	segments, err := numeric.parse(current)
+	if err != nil {
+		return "", err
+	}
+	index, err := numericSegmentIndex(kind, len(segments))
+	if err != nil {
+		return "", err
+	}
+	segments[index]++
+	for i := index + 1; i < len(segments); i++ {
+		segments[i] = 0
+	}
+	return formatNumericVersion(segments), nil
+}
+
+func (numeric *Numeric) Validate(version string) error {
+	_, err := numeric.parse(version)
+	return err
+}
+
+func (numeric *Numeric) parse(version string) ([]int, error) {
+	segments, err := parseNumericVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if numeric.Segments > 0 && len(segments) != numeric.Segments {
+		return nil, fmt.Errorf("%w %q: expected %d segments", ErrInvalidVersion, version, numeric.Segments)
+	}
+	return segments, nil
+}
+
+func parseNumericVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	segments := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := parseVersionNumber(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidVersion, version, err)
+		}
+		segments[i] = number
+	}
+	return segments, nil
+}
+
+// Returns index of the bumped segment of the version with given number of segments. Minor segment is bumped by
+// default.
+func numericSegmentIndex(kind BumpKind, count int) (int, error) {
+	index := -1
+	switch kind {
+	case MajorSegment:
+		index = 0
+	case MinorSegment, "":
+		index = 1
+	case PatchSegment:
+		index = 2
+	case RevisionSegment:
+		index = 3
+	default:
+		if position, err := strconv.Atoi(string(kind)); err == nil && position > 0 {
+			index = position - 1
+		} else {
+			return 0, fmt.Errorf("unknown version segment %q (expected %s, %s, %s, %s or position of the segment)", kind, MajorSegment, MinorSegment, PatchSegment, RevisionSegment)
+		}
+	}
+	if index >= count {
+		return 0, fmt.Errorf("cannot bump %s segment of version with %d segments", kind, count)
+	}
+	return index, nil
+}
+
+func formatNumericVersion(segments []int) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = strconv.Itoa(segment)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,60 @@
+package vrs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Formats of the reports printed by the command line tool.
+const (
+	TextOutputFormat = "text"
+	JSONOutputFormat = "json"
+)
+
+var OutputFormats = []string{TextOutputFormat, JSONOutputFormat}
+
+// Version read by ReadCurrentVersion, as reported in JSON output.
+type CurrentVersion struct {
+	Version string `json:"version"`
+	// Name of the component the version belongs to. Empty for the project itself.
+	Component string `json:"component,omitempty"`
+}
+
+// Files verified by Check, as reported in JSON output.
+type CheckReport struct {
+	Files []*CheckedFile `json:"files"`
+	// Number of the files inconsistent with the current version.
+	Inconsistent int `json:"inconsistent"`
+}
+
+func NewCheckReport(files []*CheckedFile) *CheckReport {
+	report := &CheckReport{Files: files}
+	if report.Files == nil {
+		report.Files = []*CheckedFile{}
+	}
+	for _, file := range files {
+		if file.Problem != "" {
+			report.Inconsistent++
+		}
+	}
+	return report
+}
+
+// Verifies that format is one of OutputFormats. Empty format selects the text output.
+func ValidateOutputFormat(format string) error {
+	for _, outputFormat := range append(OutputFormats, "") {
+		if format == outputFormat {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q: use one of %v", format, OutputFormats)
+}
+
+// Writes report, for example BumpResult, CurrentVersion or CheckReport, as indented JSON document, so other tools
+// can consume it without parsing human readable text.
+func EncodeJSON(writer io.Writer, report interface{}) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
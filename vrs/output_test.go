@@ -0,0 +1,49 @@
+package vrs_test
+
+import (
+	"bytes"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEncodeBumpResultAsJSON(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: VERSION\n"),
+		"VERSION": []byte("1.0.0"),
+	})
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	output := &bytes.Buffer{}
+
+	// When
+	err = vrs.EncodeJSON(output, result)
+
+	// Then
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"oldVersion": "1.0.0", "newVersion": "1.1.0",
+		"syncedFiles": [{"name": "VERSION", "path": "VERSION", "replacements": 1, "sizeDiff": 0}]}`, output.String())
+}
+
+func TestEncodeCheckReportAsJSON(t *testing.T) {
+	// Given
+	report := vrs.NewCheckReport([]*vrs.CheckedFile{{Name: "VERSION"}, {Name: "README.md", Problem: "version 1.0.0 not found"}})
+	output := &bytes.Buffer{}
+
+	// When
+	err := vrs.EncodeJSON(output, report)
+
+	// Then
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"files": [{"name": "VERSION"}, {"name": "README.md", "problem": "version 1.0.0 not found"}],
+		"inconsistent": 1}`, output.String())
+}
+
+func TestFailOnUnknownOutputFormat(t *testing.T) {
+	// When
+	err := vrs.ValidateOutputFormat("xml")
+
+	// Then
+	assert.EqualError(t, err, `unknown output format "xml": use one of [text json]`)
+}
@@ -0,0 +1,70 @@
+package vrs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Prefix of the executables implementing sync types unknown to vrs. For example the files of the acme sync type are
+// updated by vrs-sync-acme executable found in PATH.
+const SyncPluginPrefix = "vrs-sync-"
+
+// Request written by vrs to the standard input of the sync plugin.
+type SyncPluginRequest struct {
+	// Path of the synced file relative to the project directory.
+	File string `json:"file"`
+	// Content of the synced file, which has to be UTF-8 text.
+	Content    string `json:"content"`
+	OldVersion string `json:"oldVersion"`
+	NewVersion string `json:"newVersion"`
+	Path       string `json:"path,omitempty"`
+	Pattern    string `json:"pattern,omitempty"`
+	// Plugin specific settings of the sync file.
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// Response written by the sync plugin to its standard output.
+type SyncPluginResponse struct {
+	// Updated content of the file.
+	Content string `json:"content"`
+	// Number of replaced values. Zero means that the file contains no version to update.
+	Replacements int `json:"replacements"`
+	// Problem of the sync reported to the user. Content is ignored if not empty.
+	Error string `json:"error,omitempty"`
+}
+
+// Returns updater delegating to the sync plugin of the file type, executed once per synced file.
+func (file *SyncFile) pluginUpdater(executor *executor, name string, oldVersion string, newVersion string) (contentUpdater, error) {
+	plugin, err := exec.LookPath(SyncPluginPrefix + file.Type)
+	if err != nil {
+		return nil, fmt.Errorf("unknown sync file type %q: no %s%s plugin found in PATH", file.Type, SyncPluginPrefix, file.Type)
+	}
+	return func(content []byte) ([]byte, int, error) {
+		request, err := json.Marshal(&SyncPluginRequest{File: name, Content: string(content), OldVersion: oldVersion, NewVersion: newVersion,
+			Path: file.Path, Pattern: file.Pattern, Options: file.Options})
+		if err != nil {
+			return nil, 0, err
+		}
+		cmd := exec.CommandContext(executor.ctx, plugin)
+		cmd.Dir = executor.baseDir
+		cmd.Stdin = bytes.NewReader(request)
+		stderr := &bytes.Buffer{}
+		cmd.Stderr = stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, 0, fmt.Errorf("sync plugin %s failed: %w: %s", plugin, err, strings.TrimSpace(stderr.String()))
+		}
+		response := &SyncPluginResponse{}
+		err = json.Unmarshal(output, response)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid response of sync plugin %s: %w", plugin, err)
+		}
+		if response.Error != "" {
+			return nil, 0, fmt.Errorf("sync plugin %s: %s", plugin, response.Error)
+		}
+		return []byte(response.Content), response.Replacements, nil
+	}, nil
+}
@@ -0,0 +1,52 @@
+package vrs
+
+// Splits version declared with a prefix, for example v1.2.3, into the prefix and the bare version. Only the v or V
+// letter followed by a digit is treated as the prefix.
+func splitVersionPrefix(version string) (string, string) {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		return version[:1], version[1:]
+	}
+	return "", version
+}
+
+// Removes prefixes from the versions of the config and its inline components, so the versions are handled as bare
+// versions internally. The prefix found in the config file is remembered to be written back. Versions of configs with
+// explicit format are kept as declared, as the format defines its literals.
+func (config *VrsConfig) normalizeVersions() {
+	if config.Format != "" {
+		return
+	}
+	var prefix string
+	prefix, config.Version = splitVersionPrefix(config.Version)
+	for _, component := range config.Components {
+		if component.Path != "" {
+			continue
+		}
+		var componentPrefix string
+		componentPrefix, component.Version = splitVersionPrefix(component.Version)
+		prefix = firstNonEmpty(prefix, componentPrefix)
+	}
+	config.declaredPrefix = prefix
+}
+
+// Returns the version without prefix, as it is handled internally.
+func (config *VrsConfig) bareVersion(version string) string {
+	if config.Format != "" {
+		return version
+	}
+	_, version = splitVersionPrefix(version)
+	return version
+}
+
+// Returns prefix of the versions written to the config file.
+func (config *VrsConfig) versionPrefix() string {
+	return firstNonEmpty(config.VersionPrefix, config.declaredPrefix)
+}
+
+// Returns the version as written to the config file.
+func (config *VrsConfig) prefixedVersion(version string) string {
+	if version == "" {
+		return ""
+	}
+	return config.versionPrefix() + version
+}
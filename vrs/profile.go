@@ -0,0 +1,160 @@
+package vrs
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+)
+
+// Conditions activating the profile automatically, without selecting it explicitly. All the given conditions have to
+// be met.
+type Activation struct {
+	// Environment variable activating the profile, for example RELEASE_CHANNEL=beta. Variable name alone activates the
+	// profile if the variable is set to a non-empty value.
+	Env string `yaml:",omitempty"`
+	// Glob pattern of the git branch activating the profile, for example hotfix/*.
+	Branch string `yaml:",omitempty"`
+}
+
+// Prefix of the selected profile name deactivating the profile, for example !docker.
+const negatedProfilePrefix = "!"
+
+// Returns active profiles declared in the config, given the names of explicitly selected profiles. Names prefixed with
+// ! deactivate the profiles. Branch conditions are evaluated in the git repository of the working directory.
+func (config *VrsConfig) ResolveProfiles(active []string) []*Profile {
+	executor := newExecutor(context.Background(), ".", false, nil, nil, nil, nil)
+	return config.resolveProfiles(config.activeProfiles(executor, active))
+}
+
+func (config *VrsConfig) resolveProfiles(names []string) []*Profile {
+	var profiles []*Profile
+	for _, profile := range config.Profiles {
+		if containsName(names, profile.Name) {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// Returns names of the active profiles: the selected ones followed by the default profiles and the profiles activated
+// by their conditions. Profiles selected with negatedProfilePrefix are never active.
+func (config *VrsConfig) activeProfiles(executor *executor, selected []string) []string {
+	var active, deactivated []string
+	for _, name := range selected {
+		if strings.HasPrefix(name, negatedProfilePrefix) {
+			deactivated = append(deactivated, strings.TrimPrefix(name, negatedProfilePrefix))
+		}
+	}
+	for _, name := range selected {
+		if !strings.HasPrefix(name, negatedProfilePrefix) && !containsName(deactivated, name) {
+			active = append(active, name)
+		}
+	}
+	var branch *string
+	for _, profile := range config.Profiles {
+		if containsName(active, profile.Name) || containsName(deactivated, profile.Name) {
+			continue
+		}
+		if profile.Default {
+			active = append(active, profile.Name)
+			continue
+		}
+		if profile.Activation == nil {
+			continue
+		}
+		if profile.Activation.Branch != "" && branch == nil {
+			current := currentBranch(executor)
+			branch = &current
+		}
+		if profile.Activation.matches(branch) {
+			active = append(active, profile.Name)
+		}
+	}
+	return active
+}
+
+func (activation *Activation) matches(branch *string) bool {
+	if activation.Env == "" && activation.Branch == "" {
+		return false
+	}
+	if activation.Env != "" {
+		variable := strings.SplitN(activation.Env, "=", 2)
+		value := os.Getenv(variable[0])
+		if len(variable) == 1 && value == "" || len(variable) == 2 && value != variable[1] {
+			return false
+		}
+	}
+	if activation.Branch != "" {
+		matched, err := path.Match(activation.Branch, *branch)
+		if err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns name of the current git branch. CI systems checking out detached HEAD are asked for the branch of the build.
+// Empty name is returned if the branch is unknown, for example outside of git repository.
+func currentBranch(executor *executor) string {
+	branch, err := executor.git.Branch(executor.ctx)
+	if err != nil {
+		branch = ""
+	}
+	return firstNonEmpty(branch, os.Getenv("GITHUB_HEAD_REF"), os.Getenv("GITHUB_REF_NAME"), os.Getenv("CI_COMMIT_REF_NAME"))
+}
+
+type ProfilesOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to list profiles of. Empty name selects the project itself.
+	Component string
+	// Names of explicitly selected profiles, as passed to the operations changing the version.
+	ActiveProfiles []string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Client used to read the current branch. Defaults to GoGitClient reading the repository of the project directory.
+	GitClient GitClient
+}
+
+func NewDefaultProfilesOptions() (*ProfilesOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &ProfilesOptions{Basedir: basedir, UserConfigFile: defaultUserConfigFile()}, nil
+}
+
+// Profile declared in the config with its state for the given options.
+type ProfileStatus struct {
+	*Profile
+	// Whether the profile would be active in the operations changing the version.
+	Active bool
+}
+
+// Returns all the profiles declared in the config, reporting which of them would be active.
+func ListProfiles(options *ProfilesOptions) ([]*ProfileStatus, error) {
+	if options == nil {
+		o, err := NewDefaultProfilesOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
+	if err != nil {
+		return nil, err
+	}
+	executor := newExecutor(context.Background(), options.Basedir, false, nil, options.GitClient, options.FileSystem, nil)
+	active := config.activeProfiles(executor, options.ActiveProfiles)
+	var statuses []*ProfileStatus
+	for _, profile := range config.Profiles {
+		statuses = append(statuses, &ProfileStatus{Profile: profile, Active: containsName(active, profile.Name)})
+	}
+	return statuses, nil
+}
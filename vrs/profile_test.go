@@ -0,0 +1,130 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestActivateProfileByEnvironmentVariable(t *testing.T) {
+	// Given
+	setenv(t, "RELEASE_CHANNEL", "beta")
+	config := "version: 1.0.0\nprofiles:\n- name: beta\n  sync:\n    files:\n    - name: BETA\n  activation:\n    env: RELEASE_CHANNEL=beta\n" +
+		"- name: stable\n  sync:\n    files:\n    - name: STABLE\n  activation:\n    env: RELEASE_CHANNEL=stable\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "BETA": []byte("1.0.0"), "STABLE": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "BETA"))
+	assert.Equal(t, "1.0.0", readInMemory(t, fileSystem, "STABLE"))
+}
+
+func TestActivateProfileBySetEnvironmentVariable(t *testing.T) {
+	// Given
+	setenv(t, "CI", "true")
+	config := "version: 1.0.0\nprofiles:\n- name: ci\n  sync:\n    files:\n    - name: VERSION\n  activation:\n    env: CI\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "VERSION": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "VERSION"))
+}
+
+func TestActivateProfileByBranch(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nprofiles:\n- name: hotfix\n  sync:\n    files:\n    - name: HOTFIX\n  activation:\n    branch: hotfix/*\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "HOTFIX": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.PatchSegment, FileSystem: fileSystem,
+		GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{branch: "hotfix/login"}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.1", readInMemory(t, fileSystem, "HOTFIX"))
+}
+
+func TestSkipProfileOfOtherBranch(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nprofiles:\n- name: hotfix\n  sync:\n    files:\n    - name: HOTFIX\n  activation:\n    branch: hotfix/*\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "HOTFIX": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem,
+		GitOptions: vrs.GitOptions{GitClient: &recordingGitClient{branch: "main"}}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", readInMemory(t, fileSystem, "HOTFIX"))
+}
+
+func TestActivateDefaultProfile(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nprofiles:\n- name: docker\n  default: true\n  sync:\n    files:\n    - name: Dockerfile\n" +
+		"- name: helm\n  sync:\n    files:\n    - name: Chart.yaml\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "Dockerfile": []byte("1.0.0"), "Chart.yaml": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "Dockerfile"))
+	assert.Equal(t, "1.0.0", readInMemory(t, fileSystem, "Chart.yaml"))
+}
+
+func TestDeactivateDefaultProfile(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nprofiles:\n- name: docker\n  default: true\n  sync:\n    files:\n    - name: Dockerfile\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "Dockerfile": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", ActiveProfiles: []string{"!docker"}, FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", readInMemory(t, fileSystem, "Dockerfile"))
+	assert.Equal(t, "version: 1.1.0\nprofiles:\n- name: docker\n  default: true\n  sync:\n    files:\n    - name: Dockerfile\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestListProfiles(t *testing.T) {
+	// Given
+	setenv(t, "CI", "true")
+	config := "version: 1.0.0\nprofiles:\n- name: docker\n  default: true\n- name: helm\n- name: ci\n  activation:\n    env: CI\n" +
+		"- name: hotfix\n  activation:\n    branch: hotfix/*\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config)})
+
+	// When
+	profiles, err := vrs.ListProfiles(&vrs.ProfilesOptions{Basedir: ".", ActiveProfiles: []string{"helm", "!docker"},
+		FileSystem: fileSystem, GitClient: &recordingGitClient{branch: "main"}})
+
+	// Then
+	assert.NoError(t, err)
+	var active []string
+	for _, profile := range profiles {
+		if profile.Active {
+			active = append(active, profile.Name)
+		}
+	}
+	assert.Len(t, profiles, 4)
+	assert.Equal(t, []string{"helm", "ci"}, active)
+}
+
+func TestResolveProfiles(t *testing.T) {
+	// Given
+	config := &vrs.VrsConfig{Version: "1.0.0", Profiles: []*vrs.Profile{{Name: "docker", Default: true}, {Name: "helm"}}}
+
+	// When
+	profiles := config.ResolveProfiles([]string{"helm"})
+
+	// Then
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, "docker", profiles[0].Name)
+	assert.Equal(t, "helm", profiles[1].Name)
+}
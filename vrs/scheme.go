@@ -0,0 +1,93 @@
+package vrs
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	SemVerScheme  = "semver"
+	CalVerScheme  = "calver"
+	NumericScheme = "numeric"
+)
+
+type BumpKind string
+
+const (
+	MajorSegment      = "major"
+	MinorSegment      = "minor"
+	PatchSegment      = "patch"
+	PrereleaseSegment = "prerelease"
+)
+
+// Versioning scheme computing next versions of the project. Custom schemes can be registered with RegisterScheme and
+// selected using the scheme field of vrs.yml.
+type Scheme interface {
+	Next(current string, kind BumpKind) (string, error)
+	Validate(version string) error
+}
+
+var schemes = map[string]Scheme{}
+var schemesMutex sync.RWMutex
+
+func RegisterScheme(name string, scheme Scheme) {
+	schemesMutex.Lock()
+	defer schemesMutex.Unlock()
+	schemes[name] = scheme
+}
+
+// Semantic versioning scheme (https://semver.org).
+type SemVer struct {
+	// Identifier used when bumping prerelease segment. Empty value keeps the current identifier.
+	PrereleaseIdentifier string
+	// Build metadata attached to the next version.
+	Metadata string
+}
+
+func (semver *SemVer) Next(current string, kind BumpKind) (string, error) {
+	version, err := ParseVersion(current)
+	if err != nil {
+		return "", err
+	}
+	var next *Version
+	if kind == PrereleaseSegment {
+		next = version.BumpPrerelease(semver.PrereleaseIdentifier)
+	} else {
+		next, err = version.Bump(kind)
+		if err != nil {
+			return "", err
+		}
+	}
+	next.Metadata = semver.Metadata
+	return next.String(), nil
+}
+
+func (semver *SemVer) Validate(version string) error {
+	_, err := ParseVersion(version)
+	return err
+}
+
+// Resolves versioning scheme of the project. Bump options are used to configure built-in schemes and might be nil.
+func (config *VrsConfig) scheme(options *BumpOptions) (Scheme, error) {
+	if options == nil {
+		options = &BumpOptions{}
+	}
+	switch config.Scheme {
+	case "", SemVerScheme:
+		if config.Format != "" {
+			return &CustomFormat{Format: config.Format}, nil
+		}
+		return &SemVer{PrereleaseIdentifier: options.PrereleaseIdentifier, Metadata: options.Metadata}, nil
+	case CalVerScheme:
+		return &CalVer{Format: config.Format, Date: options.Date}, nil
+	case NumericScheme:
+		return &Numeric{Segments: config.Segments}, nil
+	}
+
+	schemesMutex.RLock()
+	defer schemesMutex.RUnlock()
+	if scheme, ok := schemes[config.Scheme]; ok {
+		return scheme, nil
+	}
+	return nil, fmt.Errorf("unknown versioning scheme %q", config.Scheme)
+}
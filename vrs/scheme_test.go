@@ -0,0 +1,145 @@
+package vrs_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"strconv"
+	"testing"
+)
+
+type buildNumberScheme struct{}
+
+func (scheme *buildNumberScheme) Next(current string, kind vrs.BumpKind) (string, error) {
+	number, err := strconv.Atoi(current)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(number + 1), nil
+}
+
+func (scheme *buildNumberScheme) Validate(version string) error {
+	if _, err := strconv.Atoi(version); err != nil {
+		return fmt.Errorf("invalid build number %q", version)
+	}
+	return nil
+}
+
+func TestBumpWithCustomScheme(t *testing.T) {
+	// Given
+	vrs.RegisterScheme("build-number", &buildNumberScheme{})
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "41", Scheme: "build-number"}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", version)
+}
+
+func TestParseConfigWithUnknownScheme(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Scheme: "unknown"}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.ParseVersioonConfig(basedir)
+
+	// Then
+	assert.Error(t, err)
+}
+
+func TestSemVerNext(t *testing.T) {
+	// Given
+	semver := &vrs.SemVer{PrereleaseIdentifier: "beta", Metadata: "build.7"}
+
+	// When
+	version, err := semver.Next("1.2.3", vrs.PrereleaseSegment)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4-beta.1+build.7", version)
+}
+
+func TestBumpNumericVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.2.3.4\nscheme: numeric\nsegments: 4\n"),
+	})
+
+	// When
+	revision, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.RevisionSegment, FileSystem: fileSystem})
+	assert.NoError(t, err)
+	minor, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	third, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: "3", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.5", revision.NewVersion)
+	assert.Equal(t, "1.3.0.0", minor.NewVersion)
+	assert.Equal(t, "1.3.1.0", third.NewVersion)
+}
+
+func TestFailNumericVersionWithUnexpectedSegments(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.2.3\nscheme: numeric\nsegments: 4\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+	assert.Contains(t, err.Error(), "expected 4 segments")
+}
+
+func TestFailToBumpMissingNumericSegment(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.2\nscheme: numeric\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.PatchSegment, FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, "cannot bump patch segment of version with 2 segments")
+}
+
+func TestBumpVersionWithCustomFormat(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: v1.4.2-stable\nformat: 'v{major}.{minor}.{patch}-{channel}'\n"),
+	})
+
+	// When
+	minor, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	major, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", Segment: vrs.MajorSegment, FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.5.0-stable", minor.NewVersion)
+	assert.Equal(t, "v2.0.0-stable", major.NewVersion)
+	assert.Equal(t, "version: v2.0.0-stable\nformat: 'v{major}.{minor}.{patch}-{channel}'\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestFailVersionNotMatchingCustomFormat(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.4.2\nformat: 'r{major}.{minor}'\n")})
+
+	// When
+	_, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+	assert.Contains(t, err.Error(), "expected format r{major}.{minor}")
+}
@@ -0,0 +1,154 @@
+package vrs
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// Files larger than this are synchronized without loading them into memory, if the file system supports it.
+var streamingThreshold int64 = 64 << 20
+
+const streamingChunkSize = 64 << 10
+
+// File system able to update large files without loading them into memory. OSFileSystem implements it.
+type StreamingFileSystem interface {
+	FileSystem
+	Size(name string) (int64, error)
+	Open(name string) (io.ReadCloser, error)
+	// Creates or truncates the file.
+	Create(name string, perm os.FileMode) (io.WriteCloser, error)
+	// Replaces file newName with oldName.
+	Rename(oldName string, newName string) error
+}
+
+func (fileSystem *OSFileSystem) Size(name string) (int64, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (fileSystem *OSFileSystem) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+func (fileSystem *OSFileSystem) Create(name string, perm os.FileMode) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+func (fileSystem *OSFileSystem) Rename(oldName string, newName string) error {
+	return os.Rename(oldName, newName)
+}
+
+// Literal replacement of the old version with the new one, which can be applied to the stream of the file content.
+type literalReplacement struct {
+	old   []byte
+	new   []byte
+	limit int
+}
+
+// Returns offsets of the occurrences of the old value in the content, up to the limit if it is greater than zero.
+func (replacement *literalReplacement) find(reader io.Reader) ([]int64, error) {
+	var offsets []int64
+	chunk := make([]byte, streamingChunkSize)
+	// Tail of the previous chunk, so occurrences spanning two chunks are found as well.
+	var carry []byte
+	position := int64(0)
+	for {
+		n, err := io.ReadFull(reader, chunk)
+		window := append(carry, chunk[:n]...)
+		windowStart := position - int64(len(carry))
+		searchFrom := 0
+		for {
+			index := bytes.Index(window[searchFrom:], replacement.old)
+			if index < 0 {
+				break
+			}
+			offsets = append(offsets, windowStart+int64(searchFrom+index))
+			if replacement.limit > 0 && len(offsets) == replacement.limit {
+				return offsets, nil
+			}
+			searchFrom += index + len(replacement.old)
+		}
+		position += int64(n)
+		keep := len(replacement.old) - 1
+		if keep > len(window)-searchFrom {
+			keep = len(window) - searchFrom
+		}
+		carry = append([]byte{}, window[len(window)-keep:]...)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return offsets, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Copies the content replacing length bytes at each of the offsets with the value.
+func replaceAtOffsets(reader io.Reader, writer io.Writer, offsets []int64, length int, value []byte) error {
+	bufferedWriter := bufio.NewWriterSize(writer, streamingChunkSize)
+	position := int64(0)
+	for _, offset := range offsets {
+		_, err := io.CopyN(bufferedWriter, reader, offset-position)
+		if err != nil {
+			return err
+		}
+		_, err = io.CopyN(ioutil.Discard, reader, int64(length))
+		if err != nil {
+			return err
+		}
+		_, err = bufferedWriter.Write(value)
+		if err != nil {
+			return err
+		}
+		position = offset + int64(length)
+	}
+	_, err := io.Copy(bufferedWriter, reader)
+	if err != nil {
+		return err
+	}
+	return bufferedWriter.Flush()
+}
+
+// Rewrites the file replacing length bytes at each of the offsets with the value. Content is written into temporary
+// file which then replaces the original one.
+func rewriteFile(fileSystem StreamingFileSystem, name string, offsets []int64, length int, value []byte) error {
+	mode, err := fileSystem.Mode(name)
+	if err != nil {
+		return err
+	}
+	reader, err := fileSystem.Open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	temporaryName := name + ".vrs-tmp"
+	writer, err := fileSystem.Create(temporaryName, mode)
+	if err != nil {
+		return err
+	}
+	err = replaceAtOffsets(reader, writer, offsets, length, value)
+	closeErr := writer.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = fileSystem.Remove(temporaryName)
+		return err
+	}
+	return fileSystem.Rename(temporaryName, name)
+}
+
+// Returns offsets of the replaced values in the rewritten content, given their offsets in the original content.
+func shiftOffsets(offsets []int64, oldLength int, newLength int) []int64 {
+	shifted := make([]int64, len(offsets))
+	for i, offset := range offsets {
+		shifted[i] = offset + int64(i)*int64(newLength-oldLength)
+	}
+	return shifted
+}
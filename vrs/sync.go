@@ -0,0 +1,622 @@
+package vrs
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TextSyncType = "text"
+	JSONSyncType = "json"
+	YAMLSyncType = "yaml"
+	TOMLSyncType = "toml"
+	XMLSyncType  = "xml"
+	// Updates key of Java properties file.
+	PropertiesSyncType = "properties"
+	// Updates key in the section of INI file.
+	INISyncType = "ini"
+	// Updates version of package.json and of package-lock.json placed next to it.
+	NPMSyncType = "npm"
+	// Updates crate version of Cargo.toml and the crate entry of Cargo.lock placed next to it or in the parent
+	// (workspace) directory.
+	CargoSyncType = "cargo"
+	// Updates version in pyproject.toml ([project] and [tool.poetry] tables), setup.cfg ([metadata] section) or
+	// __version__ variable of Python module.
+	PythonSyncType = "python"
+	// Updates version and appVersion of Helm chart. Updated keys can be selected with SyncFile.Keys.
+	HelmChartSyncType = "helm-chart"
+	// Updates tag of container image selected by SyncFile.Image in Kubernetes manifests.
+	ImageSyncType = "image"
+	// Replaces the whole content of the file with the version, for example VERSION file read by the build.
+	PlainSyncType = "plain"
+	// Updates ARG VERSION instruction and org.opencontainers.image.version label of Dockerfile.
+	DockerfileSyncType = "dockerfile"
+	// Updates project version of build.gradle, build.gradle.kts or gradle.properties.
+	GradleSyncType = "gradle"
+	// Moves [Unreleased] section of Keep a Changelog file into the section of the new version.
+	ChangelogSyncType = "changelog"
+)
+
+// Updates version in the content of the synced file. Returns updated content and the number of replaced values.
+type contentUpdater func(content []byte) ([]byte, int, error)
+
+// Returns updater of the synced file with given name.
+func (file *SyncFile) updater(executor *executor, name string, oldVersion string, newVersion string) (contentUpdater, error) {
+	if (file.MaxReplacements != 0 || file.First) && file.Type != "" && file.Type != TextSyncType {
+		return nil, fmt.Errorf("replacement limit of %s is supported by text sync only", name)
+	}
+	if file.Type == PlainSyncType {
+		return plainUpdater(file.Template, newVersion, executor.templateData(newVersion))
+	}
+	oldValue, newVersion, err := file.replacementValues(executor, oldVersion, newVersion)
+	if err != nil {
+		return nil, err
+	}
+	switch file.Type {
+	case "", TextSyncType:
+		maxReplacements := file.MaxReplacements
+		if file.First {
+			maxReplacements = 1
+		}
+		pattern := file.Pattern
+		if pattern == "" {
+			pattern, err = file.oldValuePattern(executor, oldVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return textUpdater(oldValue, pattern, newVersion, maxReplacements)
+	case JSONSyncType, YAMLSyncType, TOMLSyncType:
+		selector, err := parseSelector(firstNonEmpty(file.Path, "version"))
+		if err != nil {
+			return nil, err
+		}
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		switch file.Type {
+		case JSONSyncType:
+			return jsonUpdater(selector, replace), nil
+		case YAMLSyncType:
+			return yamlUpdater(selector, replace), nil
+		default:
+			return tomlUpdater(selector, replace), nil
+		}
+	case NPMSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return jsonUpdater([]selectorSegment{{key: "version"}}, replace), nil
+	case CargoSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return tomlUpdater([]selectorSegment{{key: "package"}, {key: "version"}}, replace), nil
+	case PythonSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return pythonUpdater(name, replace), nil
+	case HelmChartSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return helmChartUpdater(file.Keys, replace)
+	case ImageSyncType:
+		if file.Image == "" {
+			return nil, fmt.Errorf("image sync of %s requires image repository", name)
+		}
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return yamlNodesUpdater(func(document *yaml.Node) []*yaml.Node {
+			return findYAMLImages(document, file.Image)
+		}, func(image string) string {
+			repository, tag, _ := splitImage(image)
+			return repository + ":" + replace(tag)
+		}), nil
+	case DockerfileSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return dockerfileUpdater(replace), nil
+	case GradleSyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return gradleUpdater(name, replace), nil
+	case PropertiesSyncType, INISyncType:
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return iniUpdater(file.Section, firstNonEmpty(file.Path, "version"), replace, nil), nil
+	case ChangelogSyncType:
+		return changelogUpdater(oldVersion, newVersion, time.Now()), nil
+	case XMLSyncType:
+		steps, err := parseXPath(firstNonEmpty(file.Path, "/project/version"))
+		if err != nil {
+			return nil, err
+		}
+		replace, err := valueReplacer(file.Pattern, newVersion)
+		if err != nil {
+			return nil, err
+		}
+		return xmlUpdater(steps, replace), nil
+	default:
+		return file.pluginUpdater(executor, name, oldVersion, newVersion)
+	}
+}
+
+// Returns literal replacement of the text sync, which can be applied to the stream of the file content. Nil is
+// returned for other syncs.
+func (file *SyncFile) literalReplacement(executor *executor, oldVersion string, newVersion string) (*literalReplacement, error) {
+	if (file.Type != "" && file.Type != TextSyncType) || file.Pattern != "" {
+		return nil, nil
+	}
+	oldValue, newValue, err := file.replacementValues(executor, oldVersion, newVersion)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := file.oldValuePattern(executor, oldVersion)
+	if err != nil || pattern != "" {
+		return nil, err
+	}
+	limit := file.MaxReplacements
+	if file.First {
+		limit = 1
+	}
+	return &literalReplacement{old: []byte(oldValue), new: []byte(newValue), limit: limit}, nil
+}
+
+// Returns old and new version rendered with the replacement template of the file and wrapped with its prefix and
+// suffix.
+func (file *SyncFile) replacementValues(executor *executor, oldVersion string, newVersion string) (string, string, error) {
+	replacement := file.Replacement
+	if file.Component != "" {
+		if replacement != "" {
+			return "", "", fmt.Errorf("sync file %s can't have both component and replacement", file.Name)
+		}
+		switch file.Component {
+		case MajorSegment:
+			replacement = "{{.Major}}"
+		case "major.minor":
+			replacement = "{{.Major}}.{{.Minor}}"
+		default:
+			return "", "", fmt.Errorf("unknown version component %q (expected major or major.minor)", file.Component)
+		}
+		for _, version := range []string{oldVersion, newVersion} {
+			if _, err := ParseVersion(version); err != nil {
+				return "", "", fmt.Errorf("cannot sync version component of %s: %w", file.Name, err)
+			}
+		}
+	}
+	if replacement == "" {
+		return file.Prefix + oldVersion + file.Suffix, file.Prefix + newVersion + file.Suffix, nil
+	}
+	oldValue, err := renderTemplate("replacement", replacement, executor.templateData(oldVersion))
+	if err != nil {
+		return "", "", err
+	}
+	newValue, err := renderTemplate("replacement", replacement, executor.templateData(newVersion))
+	if err != nil {
+		return "", "", err
+	}
+	return file.Prefix + oldValue + file.Suffix, file.Prefix + newValue + file.Suffix, nil
+}
+
+// Placeholders of the values of the previous release, which are not known exactly.
+const (
+	commitSHAPlaceholder = "\x01"
+	shortSHAPlaceholder  = "\x02"
+	datePlaceholder      = "\x03"
+	versionPlaceholder   = "\x04"
+)
+
+// Returns pattern matching the old value of the text sync, if it is not known exactly. That's the case if the
+// replacement stamps the commit SHA or the date of the release, for example "{{.Version}} ({{.ShortSHA}})", or if the
+// synced version carries the build number. Any SHA, date and build number are matched then. Empty pattern is returned
+// if the old value is known.
+func (file *SyncFile) oldValuePattern(executor *executor, oldVersion string) (string, error) {
+	versionPattern := executor.buildNumber.versionPattern(oldVersion)
+	replacement := file.Replacement
+	if replacement == "" && file.Component == "" && versionPattern != "" {
+		replacement = "{{.Version}}"
+	}
+	if replacement == "" {
+		return "", nil
+	}
+	data := executor.templateData(oldVersion)
+	data.CommitSHA, data.ShortSHA, data.Date = commitSHAPlaceholder, shortSHAPlaceholder, datePlaceholder
+	if versionPattern != "" {
+		data.Version = versionPlaceholder
+	}
+	rendered, err := renderTemplate("replacement", replacement, data)
+	if err != nil {
+		return "", err
+	}
+	if !strings.ContainsAny(rendered, commitSHAPlaceholder+shortSHAPlaceholder+datePlaceholder+versionPlaceholder) {
+		return "", nil
+	}
+	return strings.NewReplacer(
+		commitSHAPlaceholder, `[0-9a-f]*`,
+		shortSHAPlaceholder, `[0-9a-f]*`,
+		datePlaceholder, `\d{4}-\d{2}-\d{2}`,
+		versionPlaceholder, versionPattern,
+	).Replace(regexp.QuoteMeta(file.Prefix + rendered + file.Suffix)), nil
+}
+
+// Returns whether the sync defines the whole content of the file, so line endings of the existing file are not
+// preserved.
+func (file *SyncFile) definesContent() bool {
+	return file.Type == PlainSyncType && file.Template != ""
+}
+
+// Returns updater writing the rendered template into the file. Without template the file contains the version only,
+// trailing newline of the file is kept.
+func plainUpdater(contentTemplate string, newVersion string, data *templateData) (contentUpdater, error) {
+	if contentTemplate != "" {
+		rendered, err := renderTemplate("plain sync", contentTemplate, data)
+		if err != nil {
+			return nil, err
+		}
+		return func([]byte) ([]byte, int, error) {
+			return []byte(rendered), 1, nil
+		}, nil
+	}
+	return func(content []byte) ([]byte, int, error) {
+		trimmed := bytes.TrimRight(content, "\r\n")
+		return append([]byte(newVersion), content[len(trimmed):]...), 1, nil
+	}, nil
+}
+
+func helmChartUpdater(keys []string, replace func(string) string) (contentUpdater, error) {
+	if len(keys) == 0 {
+		keys = []string{"version", "appVersion"}
+	}
+	var updaters []contentUpdater
+	for _, key := range keys {
+		if key != "version" && key != "appVersion" {
+			return nil, fmt.Errorf("unknown helm chart key %q (expected version or appVersion)", key)
+		}
+		updaters = append(updaters, yamlUpdater([]selectorSegment{{key: key}}, replace))
+	}
+	return chainUpdaters(updaters...), nil
+}
+
+var pythonVersionVariable = regexp.MustCompile(`(?m)^(__version__\s*=\s*)(["'])([^"'\n]*)(["'])`)
+
+// Chooses version location by the name of Python project file.
+func pythonUpdater(name string, replace func(string) string) contentUpdater {
+	switch path.Base(name) {
+	case "pyproject.toml":
+		return chainUpdaters(
+			tomlUpdater([]selectorSegment{{key: "project"}, {key: "version"}}, replace),
+			tomlUpdater([]selectorSegment{{key: "tool"}, {key: "poetry"}, {key: "version"}}, replace),
+		)
+	case "setup.cfg":
+		return iniUpdater("metadata", "version", replace, isDynamicSetuptoolsVersion)
+	default:
+		return regexpValueUpdater(pythonVersionVariable, replace)
+	}
+}
+
+var (
+	dockerfileVersionArg   = regexp.MustCompile(`(?mi)^(\s*ARG\s+VERSION=)(["']?)([^"'\s]*)(["']?)`)
+	dockerfileVersionLabel = regexp.MustCompile(`(?m)(\borg\.opencontainers\.image\.version=)(["']?)([^"'\s\\]*)(["']?)`)
+)
+
+// Updates ARG VERSION instruction and org.opencontainers.image.version label of Dockerfile.
+func dockerfileUpdater(replace func(string) string) contentUpdater {
+	return chainUpdaters(regexpValueUpdater(dockerfileVersionArg, replace), regexpValueUpdater(dockerfileVersionLabel, replace))
+}
+
+// Replaces values matched by the expression. Expression has to capture prefix, opening quote, the value and closing
+// quote.
+func regexpValueUpdater(r *regexp.Regexp, replace func(string) string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		replacements := 0
+		updated := r.ReplaceAllFunc(content, func(match []byte) []byte {
+			replacements++
+			groups := r.FindSubmatch(match)
+			return []byte(string(groups[1]) + string(groups[2]) + replace(string(groups[3])) + string(groups[4]))
+		})
+		return updated, replacements, nil
+	}
+}
+
+// Setuptools can read version from module attribute or file, for example "attr: app.__version__". Such values have to
+// be kept, the version is synced in the referenced location instead.
+func isDynamicSetuptoolsVersion(value string) bool {
+	return strings.HasPrefix(value, "attr:") || strings.HasPrefix(value, "file:")
+}
+
+// Additional file updated along with the synced file if it exists, for example lock file of the package manager.
+type companionFile struct {
+	name   string
+	update contentUpdater
+}
+
+// Returns companion files of the synced file with given name.
+func (file *SyncFile) companions(executor *executor, name string, newVersion string) ([]companionFile, error) {
+	switch file.Type {
+	case NPMSyncType:
+		replace := func(string) string {
+			return newVersion
+		}
+		return []companionFile{{
+			name: path.Join(path.Dir(name), "package-lock.json"),
+			update: chainUpdaters(
+				jsonUpdater([]selectorSegment{{key: "version"}}, replace),
+				jsonUpdater([]selectorSegment{{key: "packages"}, {key: ""}, {key: "version"}}, replace),
+			),
+		}}, nil
+	case CargoSyncType:
+		manifest, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, name))
+		if os.IsNotExist(err) {
+			// Missing manifest is reported by the sync of the manifest itself.
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries, err := scanTOML(manifest)
+		if err != nil {
+			return nil, err
+		}
+		crate := ""
+		for _, entry := range entries {
+			if selectorEquals(entry.path, []selectorSegment{{key: "package"}, {key: "name"}}) {
+				crate = entry.value
+			}
+		}
+		if crate == "" {
+			return nil, fmt.Errorf("%s has no package name", name)
+		}
+		var companions []companionFile
+		for dir := path.Dir(name); ; dir = path.Dir(dir) {
+			companions = append(companions, companionFile{name: path.Join(dir, "Cargo.lock"), update: cargoLockUpdater(crate, newVersion)})
+			if dir == "." || dir == "/" {
+				return companions, nil
+			}
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// Updates version of the local crate entry in Cargo.lock. Dependencies from registries are left intact even if they
+// have the same name.
+func cargoLockUpdater(crate string, newVersion string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		entries, err := scanTOML(content)
+		if err != nil {
+			return nil, 0, err
+		}
+		names := map[int]string{}
+		remote := map[int]bool{}
+		for _, entry := range entries {
+			if len(entry.path) == 3 && entry.path[0].key == "package" && entry.path[1].isIndex {
+				switch entry.path[2].key {
+				case "name":
+					names[entry.path[1].index] = entry.value
+				case "source":
+					remote[entry.path[1].index] = true
+				}
+			}
+		}
+		var spans [][2]int
+		var values [][]byte
+		for _, entry := range entries {
+			if len(entry.path) == 3 && entry.path[0].key == "package" && entry.path[1].isIndex && entry.path[2].key == "version" {
+				index := entry.path[1].index
+				if names[index] == crate && !remote[index] {
+					spans = append(spans, entry.span)
+					values = append(values, []byte(entry.format(newVersion)))
+				}
+			}
+		}
+		return replaceSpans(content, spans, values), len(spans), nil
+	}
+}
+
+// Returns updater applying given updaters one after another.
+func chainUpdaters(updaters ...contentUpdater) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		total := 0
+		for _, update := range updaters {
+			var replacements int
+			var err error
+			content, replacements, err = update(content)
+			if err != nil {
+				return nil, 0, err
+			}
+			total += replacements
+		}
+		return content, total, nil
+	}
+}
+
+// Returns description of the value replaced in the synced file.
+func (file *SyncFile) selector(oldVersion string) string {
+	return firstNonEmpty(file.Path, file.Pattern, oldVersion)
+}
+
+// Replaces old version or matches of the expression with the new version. Limit greater than zero restricts the
+// number of replaced occurrences, counting from the beginning of the file.
+func textUpdater(oldVersion string, oldExpression string, newVersion string, limit int) (contentUpdater, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	var r *regexp.Regexp
+	if oldExpression == "" {
+		r = regexp.MustCompile(regexp.QuoteMeta(oldVersion))
+	} else {
+		var err error
+		r, err = regexp.Compile(oldExpression)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return func(content []byte) ([]byte, int, error) {
+		matches := r.FindAllSubmatchIndex(content, limit)
+		values := make([][]byte, len(matches))
+		spans := make([][2]int, len(matches))
+		for i, match := range matches {
+			spans[i] = [2]int{match[0], match[1]}
+			if oldExpression == "" {
+				values[i] = []byte(newVersion)
+			} else {
+				values[i] = r.Expand(nil, []byte(newVersion), content, match)
+			}
+		}
+		return replaceSpans(content, spans, values), len(matches), nil
+	}, nil
+}
+
+// Returns new content of the value selected in structured file. Whole value is replaced with the new version, unless
+// the pattern is given. In such case only the parts of the value matching the pattern are replaced, for example the tag
+// of the container image.
+func valueReplacer(pattern string, newVersion string) (func(string) string, error) {
+	if pattern == "" {
+		return func(string) string {
+			return newVersion
+		}, nil
+	}
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(value string) string {
+		return r.ReplaceAllString(value, newVersion)
+	}, nil
+}
+
+// Segment of the selector addressing a value in structured document. Segment is either key of the object or index of
+// the array.
+type selectorSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (segment selectorSegment) String() string {
+	if segment.isIndex {
+		return "[" + strconv.Itoa(segment.index) + "]"
+	}
+	return "[" + strconv.Quote(segment.key) + "]"
+}
+
+// Parses selector like $.packages[""].version or spec.containers[0].image. Leading $ is optional.
+func parseSelector(selector string) ([]selectorSegment, error) {
+	remaining := strings.TrimPrefix(selector, "$")
+	var segments []selectorSegment
+	for remaining != "" {
+		switch remaining[0] {
+		case '.':
+			remaining = remaining[1:]
+			end := strings.IndexAny(remaining, ".[")
+			if end < 0 {
+				end = len(remaining)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid selector %q: empty key", selector)
+			}
+			segments = append(segments, selectorSegment{key: remaining[:end]})
+			remaining = remaining[end:]
+		case '[':
+			end := strings.Index(remaining, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid selector %q: missing ]", selector)
+			}
+			content := remaining[1:end]
+			remaining = remaining[end+1:]
+			if len(content) >= 2 && (content[0] == '"' || content[0] == '\'') && content[len(content)-1] == content[0] {
+				segments = append(segments, selectorSegment{key: content[1 : len(content)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(content)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid selector %q: invalid index %q", selector, content)
+			}
+			segments = append(segments, selectorSegment{index: index, isIndex: true})
+		default:
+			if len(segments) > 0 {
+				return nil, fmt.Errorf("invalid selector %q: unexpected character %q", selector, remaining[0])
+			}
+			remaining = "." + remaining
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid selector %q: no keys", selector)
+	}
+	return segments, nil
+}
+
+func formatSelector(path []selectorSegment) string {
+	formatted := "$"
+	for _, segment := range path {
+		formatted += segment.String()
+	}
+	return formatted
+}
+
+func selectorEquals(a []selectorSegment, b []selectorSegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Adjusts line endings and the final newline of the updated content to match the original content, so files using
+// CRLF line endings or lacking the final newline are not reformatted by the sync.
+func preserveLineEndings(original []byte, updated []byte) []byte {
+	if len(original) == 0 {
+		return updated
+	}
+	lineEnding := []byte("\n")
+	if lineCount := bytes.Count(original, []byte("\n")); lineCount > 0 && bytes.Count(original, []byte("\r\n")) == lineCount {
+		lineEnding = []byte("\r\n")
+		updated = bytes.ReplaceAll(bytes.ReplaceAll(updated, []byte("\r\n"), []byte("\n")), []byte("\n"), lineEnding)
+	}
+	originalFinalNewline, updatedFinalNewline := bytes.HasSuffix(original, []byte("\n")), bytes.HasSuffix(updated, []byte("\n"))
+	if originalFinalNewline && !updatedFinalNewline {
+		updated = append(updated, lineEnding...)
+	} else if !originalFinalNewline && updatedFinalNewline {
+		updated = bytes.TrimSuffix(bytes.TrimSuffix(updated, []byte("\n")), []byte("\r"))
+	}
+	return updated
+}
+
+// Replaces given byte spans of the content with the values. Spans have to be ordered and non-overlapping.
+func replaceSpans(content []byte, spans [][2]int, values [][]byte) []byte {
+	replaced := &bytes.Buffer{}
+	previousEnd := 0
+	for i, span := range spans {
+		replaced.Write(content[previousEnd:span[0]])
+		replaced.Write(values[i])
+		previousEnd = span[1]
+	}
+	replaced.Write(content[previousEnd:])
+	return replaced.Bytes()
+}
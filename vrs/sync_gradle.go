@@ -0,0 +1,68 @@
+package vrs
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var gradleVersionAssignment = regexp.MustCompile(`^(\s*(?:project\.)?version\s*=?\s*)(["'])([^"'\n]*)(["'])`)
+
+// Chooses version location by the name of Gradle project file. Version property of gradle.properties or top level
+// version assignment of build script is updated. Assignments nested in blocks (for example dependency constraints) are
+// left intact.
+func gradleUpdater(name string, replace func(string) string) contentUpdater {
+	if path.Base(name) == "gradle.properties" {
+		return iniUpdater("", "version", replace, nil)
+	}
+	return func(content []byte) ([]byte, int, error) {
+		var spans [][2]int
+		var values [][]byte
+		depth := 0
+		lineStart := 0
+		for lineStart < len(content) {
+			lineEnd := bytes.IndexByte(content[lineStart:], '\n')
+			if lineEnd < 0 {
+				lineEnd = len(content)
+			} else {
+				lineEnd += lineStart
+			}
+			line := string(content[lineStart:lineEnd])
+			if depth == 0 {
+				if groups := gradleVersionAssignment.FindStringSubmatchIndex(line); groups != nil {
+					spans = append(spans, [2]int{lineStart + groups[6], lineStart + groups[7]})
+					values = append(values, []byte(replace(line[groups[6]:groups[7]])))
+				}
+			}
+			depth += gradleBraceBalance(line)
+			lineStart = lineEnd + 1
+		}
+		return replaceSpans(content, spans, values), len(spans), nil
+	}
+}
+
+// Returns number of opened minus number of closed braces of the line. Braces in strings and comments are ignored.
+func gradleBraceBalance(line string) int {
+	balance := 0
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quote != 0:
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == quote {
+				quote = 0
+			}
+		case line[i] == '"' || line[i] == '\'':
+			quote = line[i]
+		case strings.HasPrefix(line[i:], "//"):
+			return balance
+		case line[i] == '{':
+			balance++
+		case line[i] == '}':
+			balance--
+		}
+	}
+	return balance
+}
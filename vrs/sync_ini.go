@@ -0,0 +1,49 @@
+package vrs
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Replaces value of the key in the section of INI file. Empty section selects keys placed before the first section.
+// Keys and values can be separated with = or :, only the value itself is rewritten. Values for which skip returns true
+// are left intact.
+func iniUpdater(section string, key string, replace func(string) string, skip func(string) bool) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		var spans [][2]int
+		var values [][]byte
+		currentSection := ""
+		lineStart := 0
+		for lineStart < len(content) {
+			lineEnd := bytes.IndexByte(content[lineStart:], '\n')
+			if lineEnd < 0 {
+				lineEnd = len(content)
+			} else {
+				lineEnd += lineStart
+			}
+			line := strings.TrimRight(string(content[lineStart:lineEnd]), "\r")
+			trimmedLine := strings.TrimSpace(line)
+			switch {
+			case trimmedLine == "" || trimmedLine[0] == '#' || trimmedLine[0] == ';':
+			case trimmedLine[0] == '[' && strings.HasSuffix(trimmedLine, "]"):
+				currentSection = strings.TrimSpace(trimmedLine[1 : len(trimmedLine)-1])
+			case currentSection == section:
+				separator := strings.IndexAny(line, "=:")
+				if separator >= 0 && strings.TrimSpace(line[:separator]) == key {
+					valueStart := separator + 1
+					for valueStart < len(line) && (line[valueStart] == ' ' || line[valueStart] == '\t') {
+						valueStart++
+					}
+					value := strings.TrimRight(line[valueStart:], " \t")
+					if skip != nil && skip(value) {
+						break
+					}
+					spans = append(spans, [2]int{lineStart + valueStart, lineStart + valueStart + len(value)})
+					values = append(values, []byte(replace(value)))
+				}
+			}
+			lineStart = lineEnd + 1
+		}
+		return replaceSpans(content, spans, values), len(spans), nil
+	}
+}
@@ -0,0 +1,87 @@
+package vrs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Replaces string value selected in JSON document. Only the value itself is rewritten, so formatting of the document is
+// preserved.
+func jsonUpdater(selector []selectorSegment, replace func(string) string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		locator := &jsonLocator{decoder: json.NewDecoder(bytes.NewReader(content)), content: content, selector: selector}
+		err := locator.value(nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		values := make([][]byte, len(locator.spans))
+		for i, value := range locator.values {
+			values[i], err = json.Marshal(replace(value))
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		return replaceSpans(content, locator.spans, values), len(locator.spans), nil
+	}
+}
+
+// Finds byte spans of JSON values matching the selector.
+type jsonLocator struct {
+	decoder  *json.Decoder
+	content  []byte
+	selector []selectorSegment
+	spans    [][2]int
+	values   []string
+}
+
+func (locator *jsonLocator) value(path []selectorSegment) error {
+	start := int(locator.decoder.InputOffset())
+	for start < len(locator.content) && bytes.IndexByte([]byte(" \t\r\n:,"), locator.content[start]) >= 0 {
+		start++
+	}
+	token, err := locator.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	switch token {
+	case json.Delim('{'):
+		for locator.decoder.More() {
+			key, err := locator.decoder.Token()
+			if err != nil {
+				return fmt.Errorf("invalid JSON: %w", err)
+			}
+			err = locator.value(appendSegment(path, selectorSegment{key: key.(string)}))
+			if err != nil {
+				return err
+			}
+		}
+		_, err = locator.decoder.Token()
+	case json.Delim('['):
+		for index := 0; locator.decoder.More(); index++ {
+			err = locator.value(appendSegment(path, selectorSegment{index: index, isIndex: true}))
+			if err != nil {
+				return err
+			}
+		}
+		_, err = locator.decoder.Token()
+	default:
+		if !selectorEquals(path, locator.selector) {
+			return nil
+		}
+		value, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("value %v at %s is not a string", token, formatSelector(path))
+		}
+		locator.spans = append(locator.spans, [2]int{start, int(locator.decoder.InputOffset())})
+		locator.values = append(locator.values, value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+func appendSegment(path []selectorSegment, segment selectorSegment) []selectorSegment {
+	return append(append([]selectorSegment{}, path...), segment)
+}
@@ -0,0 +1,647 @@
+package vrs_test
+
+import (
+	"context"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func bumpInMemory(t *testing.T, config string, files map[string]string) *vrs.MemoryFileSystem {
+	fileBytes := map[string][]byte{"vrs.yml": []byte(config)}
+	for name, content := range files {
+		fileBytes[name] = []byte(content)
+	}
+	fileSystem := vrs.NewMemoryFileSystem(fileBytes)
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	return fileSystem
+}
+
+func readInMemory(t *testing.T, fileSystem *vrs.MemoryFileSystem, name string) string {
+	content, err := fileSystem.ReadFile(name)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestJSONSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: package.json\n    type: json\n    path: $.version\n"
+	packageJSON := "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\"lib\":   \"1.0.0\"}\n}\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"package.json": packageJSON})
+
+	// Then
+	assert.Equal(t, "{\n  \"name\": \"app\",\n  \"version\": \"1.1.0\",\n  \"dependencies\": {\"lib\":   \"1.0.0\"}\n}\n", readInMemory(t, fileSystem, "package.json"))
+}
+
+func TestJSONSyncWithNestedSelector(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: lock.json\n    type: json\n    path: $.packages[\"\"].versions[1]\n"
+	lockJSON := `{"packages": {"": {"versions": ["1.0.0", "1.0.0"]}, "lib": {"versions": ["1.0.0", "1.0.0"]}}}`
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"lock.json": lockJSON})
+
+	// Then
+	assert.Equal(t, `{"packages": {"": {"versions": ["1.0.0", "1.1.0"]}, "lib": {"versions": ["1.0.0", "1.0.0"]}}}`, readInMemory(t, fileSystem, "lock.json"))
+}
+
+func TestFailOnUnknownSyncType(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":  []byte("version: 1.0.0\nsync:\n  files:\n  - name: file.bin\n    type: binary\n"),
+		"file.bin": []byte("1.0.0"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, `unknown sync file type "binary": no vrs-sync-binary plugin found in PATH`)
+}
+
+func TestSyncPlugin(t *testing.T) {
+	// Given
+	pluginDir := t.TempDir()
+	plugin := "#!/bin/sh\ncat > " + pluginDir + "/request.json\nprintf '%s' '{\"content\": \"release=1.1.0\\n\", \"replacements\": 1}'\n"
+	err := ioutil.WriteFile(path.Join(pluginDir, "vrs-sync-acme"), []byte(plugin), 0755)
+	assert.NoError(t, err)
+	setenv(t, "PATH", pluginDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: app.acme\n    type: acme\n    options:\n      key: release\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"app.acme": "release=1.0.0\n"})
+
+	// Then
+	assert.Equal(t, "release=1.1.0\n", readInMemory(t, fileSystem, "app.acme"))
+	request, err := ioutil.ReadFile(path.Join(pluginDir, "request.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"file": "app.acme", "content": "release=1.0.0\n", "oldVersion": "1.0.0", "newVersion": "1.1.0", "options": {"key": "release"}}`, string(request))
+}
+
+func TestFailedSyncPlugin(t *testing.T) {
+	// Given
+	pluginDir := t.TempDir()
+	err := ioutil.WriteFile(path.Join(pluginDir, "vrs-sync-acme"), []byte("#!/bin/sh\necho '{\"error\": \"no release key\"}'\n"), 0755)
+	assert.NoError(t, err)
+	setenv(t, "PATH", pluginDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":  []byte("version: 1.0.0\nsync:\n  files:\n  - name: app.acme\n    type: acme\n"),
+		"app.acme": []byte("1.0.0"),
+	})
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, "cannot sync file app.acme: sync plugin "+path.Join(pluginDir, "vrs-sync-acme")+": no release key")
+}
+
+func TestRunSyncPluginInProjectDirectory(t *testing.T) {
+	// Given
+	pluginDir, basedir := t.TempDir(), t.TempDir()
+	plugin := "#!/bin/sh\npwd > " + pluginDir + "/dir\nprintf '%s' '{\"content\": \"1.1.0\", \"replacements\": 1}'\n"
+	assert.NoError(t, ioutil.WriteFile(path.Join(pluginDir, "vrs-sync-acme"), []byte(plugin), 0755))
+	setenv(t, "PATH", pluginDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	assert.NoError(t, ioutil.WriteFile(path.Join(basedir, "vrs.yml"), []byte("version: 1.0.0\nsync:\n  files:\n  - name: app.acme\n    type: acme\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(path.Join(basedir, "app.acme"), []byte("1.0.0"), 0644))
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	dir, err := ioutil.ReadFile(path.Join(pluginDir, "dir"))
+	assert.NoError(t, err)
+	expectedDir, err := filepath.EvalSymlinks(basedir)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDir+"\n", string(dir))
+}
+
+func TestCancelHungSyncPlugin(t *testing.T) {
+	// Given
+	pluginDir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(path.Join(pluginDir, "vrs-sync-acme"), []byte("#!/bin/sh\nexec sleep 10\n"), 0755))
+	setenv(t, "PATH", pluginDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":  []byte("version: 1.0.0\nsync:\n  files:\n  - name: app.acme\n    type: acme\n"),
+		"app.acme": []byte("1.0.0"),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+
+	// When
+	_, err := vrs.BumpContext(ctx, &vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.Error(t, err)
+	assert.Less(t, int64(time.Since(started)), int64(5*time.Second))
+	assert.Equal(t, "1.0.0", readInMemory(t, fileSystem, "app.acme"))
+}
+
+func TestYAMLSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: deployment.yaml\n    type: yaml\n    path: spec.template.spec.containers[0].image\n    pattern: '\\d+\\.\\d+\\.\\d+'\n"
+	deployment := "# Deployment\nspec:\n  template:\n    spec:\n      containers:\n      - name: app # main container\n        image: \"registry/app:1.0.0\"\n      - name: sidecar\n        image: registry/sidecar:1.0.0\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"deployment.yaml": deployment})
+
+	// Then
+	assert.Equal(t, "# Deployment\nspec:\n  template:\n    spec:\n      containers:\n      - name: app # main container\n        image: \"registry/app:1.1.0\"\n      - name: sidecar\n        image: registry/sidecar:1.0.0\n", readInMemory(t, fileSystem, "deployment.yaml"))
+}
+
+func TestYAMLSyncOfMultipleDocuments(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: chart.yaml\n    type: yaml\n    path: appVersion\n"
+	chart := "appVersion: 1.0.0 # app\n---\nappVersion: '1.0.0'\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"chart.yaml": chart})
+
+	// Then
+	assert.Equal(t, "appVersion: 1.1.0 # app\n---\nappVersion: '1.1.0'\n", readInMemory(t, fileSystem, "chart.yaml"))
+}
+
+func TestTOMLSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: pyproject.toml\n    type: toml\n    path: tool.poetry.version\n"
+	pyproject := `# Project
+[tool.poetry]
+name = "app"
+version = "1.0.0" # released
+description = """
+[tool.poetry]
+version = "1.0.0"
+"""
+
+[tool.poetry.dependencies]
+lib = { version = "1.0.0", optional = true }
+tags = ["1.0.0", 'x']
+`
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"pyproject.toml": pyproject})
+
+	// Then
+	assert.Equal(t, `# Project
+[tool.poetry]
+name = "app"
+version = "1.1.0" # released
+description = """
+[tool.poetry]
+version = "1.0.0"
+"""
+
+[tool.poetry.dependencies]
+lib = { version = "1.0.0", optional = true }
+tags = ["1.0.0", 'x']
+`, readInMemory(t, fileSystem, "pyproject.toml"))
+}
+
+func TestTOMLSyncOfArrayTableAndInlineTable(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: Cargo.lock\n    type: toml\n    path: package[1].version\n  - name: Cargo.lock\n    type: toml\n    path: meta.deps.app.version\n"
+	lock := "[[package]]\nname = 'lib'\nversion = '1.0.0'\n\n[[package]]\nname = 'app'\nversion = '1.0.0'\n\n[meta]\ndeps.app = {version = \"1.0.0\"}\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"Cargo.lock": lock})
+
+	// Then
+	assert.Equal(t, "[[package]]\nname = 'lib'\nversion = '1.0.0'\n\n[[package]]\nname = 'app'\nversion = '1.1.0'\n\n[meta]\ndeps.app = {version = \"1.1.0\"}\n", readInMemory(t, fileSystem, "Cargo.lock"))
+}
+
+func TestTOMLSyncWithSpaceSeparatedDatetime(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: app.toml\n    type: toml\n"
+	document := "date = 1979-05-27 07:32:00Z\nlocal = 1979-05-27\nversion = \"1.0.0\"\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"app.toml": document})
+
+	// Then
+	assert.Equal(t, "date = 1979-05-27 07:32:00Z\nlocal = 1979-05-27\nversion = \"1.1.0\"\n", readInMemory(t, fileSystem, "app.toml"))
+}
+
+func TestXMLSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: pom.xml\n    type: xml\n    path: /project/version\n  - name: pom.xml\n    type: xml\n    path: /project/modules/module[2]/version\n"
+	pom := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <!-- Application -->
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency><artifactId>lib</artifactId><version>1.0.0</version></dependency>
+  </dependencies>
+  <modules>
+    <module><version>1.0.0</version></module>
+    <module><version>1.0.0</version></module>
+  </modules>
+</project>
+`
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"pom.xml": pom})
+
+	// Then
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <!-- Application -->
+  <version>1.1.0</version>
+  <dependencies>
+    <dependency><artifactId>lib</artifactId><version>1.0.0</version></dependency>
+  </dependencies>
+  <modules>
+    <module><version>1.0.0</version></module>
+    <module><version>1.1.0</version></module>
+  </modules>
+</project>
+`, readInMemory(t, fileSystem, "pom.xml"))
+}
+
+func TestXMLSyncKeepsCommentsAndCDATA(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: pom.xml\n    type: xml\n    path: /project/version\n  - name: pom.xml\n    type: xml\n    path: /project/build/version\n"
+	pom := "<project>\n  <version><!-- keep -->1.0.0</version>\n  <build><version> <![CDATA[1.0.0]]> </version></build>\n</project>\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"pom.xml": pom})
+
+	// Then
+	assert.Equal(t, "<project>\n  <version><!-- keep -->1.1.0</version>\n  <build><version> <![CDATA[1.1.0]]> </version></build>\n</project>\n", readInMemory(t, fileSystem, "pom.xml"))
+}
+
+func TestXMLSyncOfSelfClosingElement(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: pom.xml\n    type: xml\n  - name: pom.xml\n    type: xml\n    path: /project/build/version\n"
+	pom := "<project xmlns:m=\"urn:m\"><version/><build><m:version id=\"app\" /></build></project>"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"pom.xml": pom})
+
+	// Then
+	assert.Equal(t, "<project xmlns:m=\"urn:m\"><version>1.1.0</version><build><m:version id=\"app\">1.1.0</m:version></build></project>",
+		readInMemory(t, fileSystem, "pom.xml"))
+}
+
+func TestFailOnXMLMixedContent(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: pom.xml\n    type: xml\n"),
+		"pom.xml": []byte("<project><version>1.0<!-- split -->.0</version></project>"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mixed content")
+}
+
+func TestNPMSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: web/package.json\n    type: npm\n"
+	packageJSON := "{\n  \"name\": \"web\",\n  \"version\": \"1.0.0\"\n}\n"
+	lock := "{\n  \"name\": \"web\",\n  \"version\": \"1.0.0\",\n  \"packages\": {\n    \"\": {\"version\": \"1.0.0\"},\n    \"node_modules/lib\": {\"version\": \"1.0.0\"}\n  }\n}\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"web/package.json": packageJSON, "web/package-lock.json": lock})
+
+	// Then
+	assert.Equal(t, "{\n  \"name\": \"web\",\n  \"version\": \"1.1.0\"\n}\n", readInMemory(t, fileSystem, "web/package.json"))
+	assert.Equal(t, "{\n  \"name\": \"web\",\n  \"version\": \"1.1.0\",\n  \"packages\": {\n    \"\": {\"version\": \"1.1.0\"},\n    \"node_modules/lib\": {\"version\": \"1.0.0\"}\n  }\n}\n", readInMemory(t, fileSystem, "web/package-lock.json"))
+}
+
+func TestNPMSyncWithoutLockFile(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: package.json\n    type: npm\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"package.json": `{"version": "1.0.0"}`})
+
+	// Then
+	assert.Equal(t, `{"version": "1.1.0"}`, readInMemory(t, fileSystem, "package.json"))
+}
+
+func TestCargoSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: crates/app/Cargo.toml\n    type: cargo\n"
+	manifest := "[package]\nname = \"app\"\nversion = \"1.0.0\"\n\n[dependencies]\nlib = \"1.0.0\"\n"
+	lock := "version = 3\n\n[[package]]\nname = \"app\"\nversion = \"1.0.0\"\n\n[[package]]\nname = \"app\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"crates/app/Cargo.toml": manifest, "Cargo.lock": lock})
+
+	// Then
+	assert.Equal(t, "[package]\nname = \"app\"\nversion = \"1.1.0\"\n\n[dependencies]\nlib = \"1.0.0\"\n", readInMemory(t, fileSystem, "crates/app/Cargo.toml"))
+	assert.Equal(t, "version = 3\n\n[[package]]\nname = \"app\"\nversion = \"1.1.0\"\n\n[[package]]\nname = \"app\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n", readInMemory(t, fileSystem, "Cargo.lock"))
+}
+
+func TestPythonSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: pyproject.toml\n    type: python\n  - name: setup.cfg\n    type: python\n  - name: app/__init__.py\n    type: python\n"
+	files := map[string]string{
+		"pyproject.toml":  "[project]\nname = \"app\"\nversion = \"1.0.0\"\ndependencies = [\"lib==1.0.0\"]\n",
+		"setup.cfg":       "[metadata]\nname = app\nversion = 1.0.0\n\n[options]\nversion = 1.0.0\n",
+		"app/__init__.py": "__version__ = '1.0.0'\nLIB_VERSION = '1.0.0'\n",
+	}
+
+	// When
+	fileSystem := bumpInMemory(t, config, files)
+
+	// Then
+	assert.Equal(t, "[project]\nname = \"app\"\nversion = \"1.1.0\"\ndependencies = [\"lib==1.0.0\"]\n", readInMemory(t, fileSystem, "pyproject.toml"))
+	assert.Equal(t, "[metadata]\nname = app\nversion = 1.1.0\n\n[options]\nversion = 1.0.0\n", readInMemory(t, fileSystem, "setup.cfg"))
+	assert.Equal(t, "__version__ = '1.1.0'\nLIB_VERSION = '1.0.0'\n", readInMemory(t, fileSystem, "app/__init__.py"))
+}
+
+func TestPythonSyncKeepsDynamicSetupCfgVersion(t *testing.T) {
+	for _, version := range []string{"attr: app.__version__", "file: VERSION"} {
+		// Given
+		config := "version: 1.0.0\nsync:\n  files:\n  - name: setup.cfg\n    type: python\n"
+		setupCfg := "[metadata]\nname = app\nversion = " + version + "\n"
+
+		// When
+		fileSystem := bumpInMemory(t, config, map[string]string{"setup.cfg": setupCfg})
+
+		// Then
+		assert.Equal(t, setupCfg, readInMemory(t, fileSystem, "setup.cfg"))
+	}
+}
+
+func TestHelmChartSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: charts/app/Chart.yaml\n    type: helm-chart\n  - name: charts/lib/Chart.yaml\n    type: helm-chart\n    keys: [appVersion]\n"
+	chart := "apiVersion: v2\nname: app\nversion: 1.0.0\nappVersion: \"1.0.0\"\n"
+	libChart := "apiVersion: v2\nname: lib\nversion: 0.3.0\nappVersion: \"1.0.0\"\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"charts/app/Chart.yaml": chart, "charts/lib/Chart.yaml": libChart})
+
+	// Then
+	assert.Equal(t, "apiVersion: v2\nname: app\nversion: 1.1.0\nappVersion: \"1.1.0\"\n", readInMemory(t, fileSystem, "charts/app/Chart.yaml"))
+	assert.Equal(t, "apiVersion: v2\nname: lib\nversion: 0.3.0\nappVersion: \"1.1.0\"\n", readInMemory(t, fileSystem, "charts/lib/Chart.yaml"))
+}
+
+func TestImageSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: deploy/app.yaml\n    type: image\n    image: registry:5000/myorg/app\n"
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      initContainers:
+      - image: registry:5000/myorg/app:1.0.0 # migrations
+      containers:
+      - name: app
+        image: "registry:5000/myorg/app:1.0.0"
+      - name: proxy
+        image: myorg/proxy:1.0.0
+---
+kind: StatefulSet
+spec:
+  template:
+    spec:
+      containers:
+      - image: registry:5000/myorg/app:1.0.0
+`
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"deploy/app.yaml": manifest})
+
+	// Then
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      initContainers:
+      - image: registry:5000/myorg/app:1.1.0 # migrations
+      containers:
+      - name: app
+        image: "registry:5000/myorg/app:1.1.0"
+      - name: proxy
+        image: myorg/proxy:1.0.0
+---
+kind: StatefulSet
+spec:
+  template:
+    spec:
+      containers:
+      - image: registry:5000/myorg/app:1.1.0
+`, readInMemory(t, fileSystem, "deploy/app.yaml"))
+}
+
+func TestDockerfileSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: Dockerfile\n    type: dockerfile\n"
+	dockerfile := "FROM alpine:1.0.0\nARG VERSION=1.0.0\narg version=\"1.0.0\"\nLABEL org.opencontainers.image.title=app \\\n      org.opencontainers.image.version=\"1.0.0\"\nRUN echo 1.0.0\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"Dockerfile": dockerfile})
+
+	// Then
+	assert.Equal(t, "FROM alpine:1.0.0\nARG VERSION=1.1.0\narg version=\"1.1.0\"\nLABEL org.opencontainers.image.title=app \\\n      org.opencontainers.image.version=\"1.1.0\"\nRUN echo 1.0.0\n", readInMemory(t, fileSystem, "Dockerfile"))
+}
+
+func TestGradleSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: build.gradle.kts\n    type: gradle\n  - name: gradle.properties\n    type: gradle\n"
+	buildScript := `plugins { id("app") version "1.0.0" }
+version = "1.0.0" // released
+
+dependencies {
+    implementation("org:lib:1.0.0")
+    constraints {
+        implementation("org:other") { version = "1.0.0" }
+    }
+}
+`
+	properties := "# Project\ngroup=org\nversion=1.0.0\nlibVersion=1.0.0\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"build.gradle.kts": buildScript, "gradle.properties": properties})
+
+	// Then
+	assert.Equal(t, `plugins { id("app") version "1.0.0" }
+version = "1.1.0" // released
+
+dependencies {
+    implementation("org:lib:1.0.0")
+    constraints {
+        implementation("org:other") { version = "1.0.0" }
+    }
+}
+`, readInMemory(t, fileSystem, "build.gradle.kts"))
+	assert.Equal(t, "# Project\ngroup=org\nversion=1.1.0\nlibVersion=1.0.0\n", readInMemory(t, fileSystem, "gradle.properties"))
+}
+
+func TestPropertiesAndINISync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: app.properties\n    type: properties\n    path: app.version\n  - name: app.ini\n    type: ini\n    section: app\n"
+	properties := "# Application\napp.name=app\napp.version = 1.0.0\nlib.version=1.0.0\n"
+	ini := "version=1.0.0\n\n[app]\n; released\nversion = 1.0.0\n\n[lib]\nversion = 1.0.0\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"app.properties": properties, "app.ini": ini})
+
+	// Then
+	assert.Equal(t, "# Application\napp.name=app\napp.version = 1.1.0\nlib.version=1.0.0\n", readInMemory(t, fileSystem, "app.properties"))
+	assert.Equal(t, "version=1.0.0\n\n[app]\n; released\nversion = 1.1.0\n\n[lib]\nversion = 1.0.0\n", readInMemory(t, fileSystem, "app.ini"))
+}
+
+func TestPlainSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: VERSION\n    type: plain\n  - name: TAG\n    type: plain\n    template: \"v{{.Version}}\\n\"\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"VERSION": "0.9.0\n", "TAG": "unknown\n"})
+
+	// Then
+	assert.Equal(t, "1.1.0\n", readInMemory(t, fileSystem, "VERSION"))
+	assert.Equal(t, "v1.1.0\n", readInMemory(t, fileSystem, "TAG"))
+}
+
+func TestPlainSyncTemplateDefinesWholeContent(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: TAG\n    type: plain\n    template: \"v{{.Version}}\\n\"\n" +
+		"  - name: RELEASE\n    type: plain\n    template: \"version={{.Version}}\\r\\nchannel=stable\\n\"\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"TAG": "v1.0.0", "RELEASE": "version=1.0.0\r\n"})
+
+	// Then
+	assert.Equal(t, "v1.1.0\n", readInMemory(t, fileSystem, "TAG"))
+	assert.Equal(t, "version=1.1.0\r\nchannel=stable\n", readInMemory(t, fileSystem, "RELEASE"))
+}
+
+func TestSyncWithReplacementTemplate(t *testing.T) {
+	// Given
+	config := "version: 1.4.2\nsync:\n  files:\n  - name: docs.md\n    replacement: '{{.Major}}.{{.Minor}}'\n  - name: package.json\n    type: json\n    replacement: v{{.Version}}\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{
+		"docs.md":      "See https://example.com/docs/1.4/ for details.\n",
+		"package.json": "{\"version\": \"v1.4.2\"}",
+	})
+
+	// Then
+	assert.Equal(t, "See https://example.com/docs/1.5/ for details.\n", readInMemory(t, fileSystem, "docs.md"))
+	assert.Equal(t, "{\"version\": \"v1.5.0\"}", readInMemory(t, fileSystem, "package.json"))
+}
+
+type headGitClient struct {
+	recordingGitClient
+	head string
+}
+
+func (client *headGitClient) Head(ctx context.Context) (string, error) {
+	return client.head, nil
+}
+
+func TestSyncReplacementStampingCommit(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: 1.4.2\nsync:\n  files:\n  - name: build.txt\n" +
+			"    replacement: '{{.Version}} ({{.ShortSHA}}, {{.Date}})'\n"),
+		"build.txt": []byte("Build 1.4.2 (0123abc, 2020-01-31).\n"),
+	})
+	git := &headGitClient{head: "4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f"}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "Build 1.5.0 (4e5f6a7, "+time.Now().Format("2006-01-02")+").\n", readInMemory(t, fileSystem, "build.txt"))
+}
+
+func TestSyncVersionComponent(t *testing.T) {
+	// Given
+	config := "version: 1.4.7\nsync:\n  files:\n  - name: README.md\n    component: major.minor\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"README.md": "![version](https://img.shields.io/badge/version-1.4-blue)\n"})
+
+	// Then
+	assert.Equal(t, "![version](https://img.shields.io/badge/version-1.5-blue)\n", readInMemory(t, fileSystem, "README.md"))
+}
+
+func TestFailOnUnknownVersionComponent(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":   []byte("version: 1.0.0\nsync:\n  files:\n  - name: README.md\n    component: patch\n"),
+		"README.md": []byte("1.0.0"),
+	})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.EqualError(t, err, `unknown version component "patch" (expected major or major.minor)`)
+}
+
+func TestSyncWithPrefixAndSuffix(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: install.sh\n    prefix: v\n    suffix: -release\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"install.sh": "curl https://example.com/v1.0.0-release/app # requires lib 1.0.0\n"})
+
+	// Then
+	assert.Equal(t, "curl https://example.com/v1.1.0-release/app # requires lib 1.0.0\n", readInMemory(t, fileSystem, "install.sh"))
+}
+
+func TestSyncFirstOccurrences(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: CHANGELOG.md\n    first: true\n  - name: versions.txt\n    pattern: 'v\\d+\\.\\d+\\.\\d+'\n    maxReplacements: 2\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{
+		"CHANGELOG.md": "Current: 1.0.0\n\n## 1.0.0\n",
+		"versions.txt": "v1.0.0 v1.0.0 v0.9.0\n",
+	})
+
+	// Then
+	assert.Equal(t, "Current: 1.1.0\n\n## 1.0.0\n", readInMemory(t, fileSystem, "CHANGELOG.md"))
+	assert.Equal(t, "1.1.0 1.1.0 v0.9.0\n", readInMemory(t, fileSystem, "versions.txt"))
+}
+
+func TestSyncPreservesLineEndings(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\r\nsync:\r\n  files:\r\n  - name: VERSION\r\n  - name: app.txt\r\n    type: plain\r\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"VERSION": "1.0.0\r\n", "app.txt": "1.0.0"})
+
+	// Then
+	assert.Equal(t, "1.1.0\r\n", readInMemory(t, fileSystem, "VERSION"))
+	assert.Equal(t, "1.1.0", readInMemory(t, fileSystem, "app.txt"))
+	assert.Contains(t, readInMemory(t, fileSystem, "vrs.yml"), "version: 1.1.0\r\n")
+}
+
+func TestChangelogSync(t *testing.T) {
+	// Given
+	config := "version: 1.0.0\nsync:\n  files:\n  - name: CHANGELOG.md\n    type: changelog\n"
+	changelog := "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- Streaming API.\n\n## [1.0.0] - 2020-01-01\n\n- Initial release.\n\n" +
+		"[Unreleased]: https://github.com/org/app/compare/v1.0.0...HEAD\n[1.0.0]: https://github.com/org/app/releases/tag/v1.0.0\n"
+
+	// When
+	fileSystem := bumpInMemory(t, config, map[string]string{"CHANGELOG.md": changelog})
+
+	// Then
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] - "+time.Now().Format("2006-01-02")+"\n\n### Added\n\n- Streaming API.\n\n"+
+		"## [1.0.0] - 2020-01-01\n\n- Initial release.\n\n[Unreleased]: https://github.com/org/app/compare/v1.1.0...HEAD\n"+
+		"[1.1.0]: https://github.com/org/app/compare/v1.0.0...v1.1.0\n[1.0.0]: https://github.com/org/app/releases/tag/v1.0.0\n",
+		readInMemory(t, fileSystem, "CHANGELOG.md"))
+}
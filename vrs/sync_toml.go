@@ -0,0 +1,321 @@
+package vrs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Replaces string value selected in TOML document. Only the value itself is rewritten, so comments and table layout
+// of the document are preserved. Elements of array tables are selected by index, for example package[0].version.
+func tomlUpdater(selector []selectorSegment, replace func(string) string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		entries, err := scanTOML(content)
+		if err != nil {
+			return nil, 0, err
+		}
+		var spans [][2]int
+		var values [][]byte
+		for _, entry := range entries {
+			if selectorEquals(entry.path, selector) {
+				spans = append(spans, entry.span)
+				values = append(values, []byte(entry.format(replace(entry.value))))
+			}
+		}
+		return replaceSpans(content, spans, values), len(spans), nil
+	}
+}
+
+// String value of TOML document.
+type tomlEntry struct {
+	path  []selectorSegment
+	value string
+	// Byte span of the value in the document, including quotes.
+	span    [2]int
+	literal bool
+}
+
+func (entry *tomlEntry) format(value string) string {
+	if entry.literal && !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	return strconv.Quote(value)
+}
+
+// Scans TOML document and returns its single line string values. Other values are skipped.
+func scanTOML(content []byte) ([]*tomlEntry, error) {
+	scanner := &tomlScanner{content: content, arrayTables: map[string]int{}}
+	err := scanner.scan()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOML at byte %d: %w", scanner.pos, err)
+	}
+	return scanner.entries, nil
+}
+
+type tomlScanner struct {
+	content []byte
+	pos     int
+	table   []selectorSegment
+	// Number of elements of array tables, by table path.
+	arrayTables map[string]int
+	entries     []*tomlEntry
+}
+
+func (scanner *tomlScanner) scan() error {
+	for {
+		scanner.skipBlank(true)
+		if scanner.pos >= len(scanner.content) {
+			return nil
+		}
+		var err error
+		if scanner.content[scanner.pos] == '[' {
+			err = scanner.tableHeader()
+		} else {
+			err = scanner.keyValue(scanner.table)
+		}
+		if err != nil {
+			return err
+		}
+		scanner.skipBlank(false)
+		if scanner.pos < len(scanner.content) && scanner.content[scanner.pos] != '\n' && scanner.content[scanner.pos] != '\r' {
+			return fmt.Errorf("unexpected character %q", scanner.content[scanner.pos])
+		}
+	}
+}
+
+// Skips whitespace and comments. Newlines are skipped only if requested.
+func (scanner *tomlScanner) skipBlank(newlines bool) {
+	for scanner.pos < len(scanner.content) {
+		switch scanner.content[scanner.pos] {
+		case ' ', '\t':
+			scanner.pos++
+		case '\n', '\r':
+			if !newlines {
+				return
+			}
+			scanner.pos++
+		case '#':
+			for scanner.pos < len(scanner.content) && scanner.content[scanner.pos] != '\n' {
+				scanner.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (scanner *tomlScanner) tableHeader() error {
+	array := strings.HasPrefix(string(scanner.content[scanner.pos:]), "[[")
+	if array {
+		scanner.pos += 2
+	} else {
+		scanner.pos++
+	}
+	keys, err := scanner.key()
+	if err != nil {
+		return err
+	}
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(string(scanner.content[scanner.pos:]), closing) {
+		return fmt.Errorf("unterminated table header")
+	}
+	scanner.pos += len(closing)
+
+	// Keys referring to array tables address their last element.
+	var table []selectorSegment
+	for i, key := range keys {
+		table = append(table, selectorSegment{key: key})
+		if array && i == len(keys)-1 {
+			break
+		}
+		if count, ok := scanner.arrayTables[formatSelector(table)]; ok {
+			table = append(table, selectorSegment{index: count - 1, isIndex: true})
+		}
+	}
+	if array {
+		tablePath := formatSelector(table)
+		table = append(table, selectorSegment{index: scanner.arrayTables[tablePath], isIndex: true})
+		scanner.arrayTables[tablePath]++
+	}
+	scanner.table = table
+	return nil
+}
+
+func (scanner *tomlScanner) keyValue(table []selectorSegment) error {
+	keys, err := scanner.key()
+	if err != nil {
+		return err
+	}
+	if scanner.pos >= len(scanner.content) || scanner.content[scanner.pos] != '=' {
+		return fmt.Errorf("expected = after key %s", strings.Join(keys, "."))
+	}
+	scanner.pos++
+	scanner.skipBlank(false)
+	path := append([]selectorSegment{}, table...)
+	for _, key := range keys {
+		path = append(path, selectorSegment{key: key})
+	}
+	return scanner.value(path)
+}
+
+// Parses dotted key consisting of bare and quoted keys.
+func (scanner *tomlScanner) key() ([]string, error) {
+	var keys []string
+	for {
+		scanner.skipBlank(false)
+		if scanner.pos >= len(scanner.content) {
+			return nil, fmt.Errorf("unexpected end of document")
+		}
+		switch scanner.content[scanner.pos] {
+		case '"', '\'':
+			start := scanner.pos
+			value, err := scanner.quotedString()
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, fmt.Errorf("invalid key at byte %d", start)
+			}
+			keys = append(keys, *value)
+		default:
+			start := scanner.pos
+			for scanner.pos < len(scanner.content) && isTOMLBareKeyCharacter(scanner.content[scanner.pos]) {
+				scanner.pos++
+			}
+			if start == scanner.pos {
+				return nil, fmt.Errorf("unexpected character %q", scanner.content[scanner.pos])
+			}
+			keys = append(keys, string(scanner.content[start:scanner.pos]))
+		}
+		scanner.skipBlank(false)
+		if scanner.pos >= len(scanner.content) || scanner.content[scanner.pos] != '.' {
+			return keys, nil
+		}
+		scanner.pos++
+	}
+}
+
+func isTOMLBareKeyCharacter(character byte) bool {
+	return isAlphanumeric(rune(character)) || character == '-' || character == '_'
+}
+
+func (scanner *tomlScanner) value(path []selectorSegment) error {
+	if scanner.pos >= len(scanner.content) {
+		return fmt.Errorf("missing value")
+	}
+	start := scanner.pos
+	switch scanner.content[scanner.pos] {
+	case '"', '\'':
+		literal := scanner.content[scanner.pos] == '\''
+		value, err := scanner.quotedString()
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			scanner.entries = append(scanner.entries, &tomlEntry{path: path, value: *value, span: [2]int{start, scanner.pos}, literal: literal})
+		}
+		return nil
+	case '{':
+		scanner.pos++
+		for {
+			scanner.skipBlank(false)
+			if scanner.pos < len(scanner.content) && scanner.content[scanner.pos] == '}' {
+				scanner.pos++
+				return nil
+			}
+			err := scanner.keyValue(path)
+			if err != nil {
+				return err
+			}
+			scanner.skipBlank(false)
+			if scanner.pos < len(scanner.content) && scanner.content[scanner.pos] == ',' {
+				scanner.pos++
+			}
+		}
+	case '[':
+		scanner.pos++
+		for index := 0; ; index++ {
+			scanner.skipBlank(true)
+			if scanner.pos < len(scanner.content) && scanner.content[scanner.pos] == ']' {
+				scanner.pos++
+				return nil
+			}
+			err := scanner.value(append(append([]selectorSegment{}, path...), selectorSegment{index: index, isIndex: true}))
+			if err != nil {
+				return err
+			}
+			scanner.skipBlank(true)
+			if scanner.pos < len(scanner.content) && scanner.content[scanner.pos] == ',' {
+				scanner.pos++
+			}
+		}
+	default:
+		scanner.bareValue()
+		if start == scanner.pos {
+			return fmt.Errorf("unexpected character %q", scanner.content[scanner.pos])
+		}
+		// Date and time of datetime value can be separated with space, for example 1979-05-27 07:32:00Z.
+		if tomlLocalDate.Match(scanner.content[start:scanner.pos]) && scanner.pos+1 < len(scanner.content) &&
+			scanner.content[scanner.pos] == ' ' && scanner.content[scanner.pos+1] >= '0' && scanner.content[scanner.pos+1] <= '9' {
+			scanner.pos++
+			scanner.bareValue()
+		}
+		return nil
+	}
+}
+
+var tomlLocalDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// Skips number, boolean, date or time value.
+func (scanner *tomlScanner) bareValue() {
+	for scanner.pos < len(scanner.content) && !strings.ContainsRune(" \t\r\n,]}#", rune(scanner.content[scanner.pos])) {
+		scanner.pos++
+	}
+}
+
+// Parses basic or literal string. Multi-line strings are skipped and nil value is returned for them.
+func (scanner *tomlScanner) quotedString() (*string, error) {
+	quote := scanner.content[scanner.pos]
+	delimiter := strings.Repeat(string(quote), 3)
+	if strings.HasPrefix(string(scanner.content[scanner.pos:]), delimiter) {
+		end := strings.Index(string(scanner.content[scanner.pos+3:]), delimiter)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated multi-line string")
+		}
+		scanner.pos += 3 + end + 3
+		// Closing delimiter can be followed by up to two quotes belonging to the string.
+		for i := 0; i < 2 && scanner.pos < len(scanner.content) && scanner.content[scanner.pos] == quote; i++ {
+			scanner.pos++
+		}
+		return nil, nil
+	}
+
+	start := scanner.pos
+	for scanner.pos++; scanner.pos < len(scanner.content); scanner.pos++ {
+		switch scanner.content[scanner.pos] {
+		case '\n':
+			return nil, fmt.Errorf("unterminated string")
+		case '\\':
+			if quote == '"' {
+				scanner.pos++
+			}
+		case quote:
+			scanner.pos++
+			raw := string(scanner.content[start:scanner.pos])
+			if quote == '\'' {
+				value := raw[1 : len(raw)-1]
+				return &value, nil
+			}
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", raw)
+			}
+			return &value, nil
+		}
+	}
+	return nil, fmt.Errorf("unterminated string")
+}
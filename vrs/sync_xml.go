@@ -0,0 +1,149 @@
+package vrs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Step of the XPath selecting child element by local name. Index is 1-based, zero index selects all elements with the
+// name.
+type xpathStep struct {
+	name  string
+	index int
+}
+
+// Parses absolute XPath subset consisting of element names and positions, for example /project/version or
+// /project/dependencies/dependency[2]/version.
+func parseXPath(xpath string) ([]xpathStep, error) {
+	if !strings.HasPrefix(xpath, "/") || strings.HasPrefix(xpath, "//") {
+		return nil, fmt.Errorf("invalid XPath %q: only absolute paths are supported", xpath)
+	}
+	var steps []xpathStep
+	for _, part := range strings.Split(xpath[1:], "/") {
+		step := xpathStep{name: part}
+		if bracketIndex := strings.Index(part, "["); bracketIndex >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("invalid XPath %q: missing ]", xpath)
+			}
+			index, err := strconv.Atoi(part[bracketIndex+1 : len(part)-1])
+			if err != nil || index < 1 {
+				return nil, fmt.Errorf("invalid XPath %q: invalid position %q", xpath, part[bracketIndex+1:len(part)-1])
+			}
+			step = xpathStep{name: part[:bracketIndex], index: index}
+		}
+		if step.name == "" {
+			return nil, fmt.Errorf("invalid XPath %q: empty element name", xpath)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// Replaces text of the elements selected in XML document. Only the text itself is rewritten, so formatting, comments
+// and other occurrences of the version (for example in dependencies) are left intact. Elements with text split by
+// comments or processing instructions are rejected, as it is not clear which part of the text holds the version.
+func xmlUpdater(steps []xpathStep, replace func(string) string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		// Elements of the current path with counts of their child elements by name.
+		type openElement struct {
+			matched    bool
+			childCount map[string]int
+		}
+		stack := []*openElement{{matched: true, childCount: map[string]int{}}}
+		var spans [][2]int
+		var values [][]byte
+		// Text of the selected element, excluding surrounding whitespace.
+		textStart, textSpan, text, textValid, textCount := -1, [2]int{}, "", false, 0
+		// Offset of the start tag of the selected element.
+		elementStart := -1
+		cdata := false
+		for {
+			offset := int(decoder.InputOffset())
+			token, err := decoder.Token()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid XML: %w", err)
+			}
+			switch element := token.(type) {
+			case xml.StartElement:
+				parent := stack[len(stack)-1]
+				parent.childCount[element.Name.Local]++
+				depth := len(stack) - 1
+				matched := parent.matched && depth < len(steps) && steps[depth].name == element.Name.Local &&
+					(steps[depth].index == 0 || steps[depth].index == parent.childCount[element.Name.Local])
+				stack = append(stack, &openElement{matched: matched, childCount: map[string]int{}})
+				textStart, textValid, textCount = -1, false, 0
+				if matched && len(stack)-1 == len(steps) {
+					elementStart, textStart, textValid = offset, int(decoder.InputOffset()), true
+				}
+			case xml.CharData:
+				if !textValid || strings.TrimSpace(string(element)) == "" {
+					continue
+				}
+				textCount++
+				raw := string(content[offset:decoder.InputOffset()])
+				cdata = strings.HasPrefix(raw, "<![CDATA[")
+				if cdata {
+					textSpan = [2]int{offset + len("<![CDATA["), int(decoder.InputOffset()) - len("]]>")}
+					text = string(element)
+				} else {
+					trimmed := strings.TrimLeft(raw, " \t\r\n")
+					textSpan = [2]int{offset + len(raw) - len(trimmed), offset + len(strings.TrimRight(raw, " \t\r\n"))}
+					text = strings.TrimSpace(string(element))
+				}
+			case xml.EndElement:
+				if textValid && textStart >= 0 {
+					switch {
+					case textCount > 1:
+						return nil, 0, fmt.Errorf("element %s at byte %d has mixed content", element.Name.Local, textStart)
+					case textCount == 0:
+						escaped := &bytes.Buffer{}
+						err = xml.EscapeText(escaped, []byte(replace("")))
+						if err != nil {
+							return nil, 0, err
+						}
+						if startTag := string(content[elementStart:textStart]); strings.HasSuffix(startTag, "/>") {
+							// Self-closing element has no room for the text, so it is expanded into start and end tags.
+							spans = append(spans, [2]int{elementStart, textStart})
+							values = append(values, expandEmptyElement(startTag, escaped.Bytes()))
+							break
+						}
+						spans = append(spans, [2]int{textStart, offset})
+						values = append(values, escaped.Bytes())
+					case cdata:
+						spans = append(spans, textSpan)
+						values = append(values, []byte(replace(text)))
+					default:
+						escaped := &bytes.Buffer{}
+						err = xml.EscapeText(escaped, []byte(replace(text)))
+						if err != nil {
+							return nil, 0, err
+						}
+						spans = append(spans, textSpan)
+						values = append(values, escaped.Bytes())
+					}
+				}
+				textValid = false
+				stack = stack[:len(stack)-1]
+			}
+		}
+		return replaceSpans(content, spans, values), len(spans), nil
+	}
+}
+
+// Rewrites self-closing tag, for example <version/>, into the element containing given text.
+func expandEmptyElement(tag string, text []byte) []byte {
+	name := strings.FieldsFunc(tag[1:], func(r rune) bool {
+		return r == '/' || r == '>' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})[0]
+	expanded := strings.TrimRight(strings.TrimSuffix(tag, "/>"), " \t\r\n") + ">" + string(text) + "</" + name + ">"
+	return []byte(expanded)
+}
@@ -0,0 +1,167 @@
+package vrs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Replaces scalar value selected in YAML document. Only the scalar itself is rewritten, so comments and layout of the
+// document are preserved. Selector is applied to every document of the multi-document file.
+func yamlUpdater(selector []selectorSegment, replace func(string) string) contentUpdater {
+	return yamlNodesUpdater(func(document *yaml.Node) []*yaml.Node {
+		return findYAMLNodes(document, nil, selector)
+	}, replace)
+}
+
+// Replaces scalars returned by find function for every document of the YAML file.
+func yamlNodesUpdater(find func(document *yaml.Node) []*yaml.Node, replace func(string) string) contentUpdater {
+	return func(content []byte) ([]byte, int, error) {
+		var nodes []*yaml.Node
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		for {
+			document := &yaml.Node{}
+			err := decoder.Decode(document)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid YAML: %w", err)
+			}
+			nodes = append(nodes, find(document)...)
+		}
+
+		lineStarts := []int{0}
+		for i, character := range content {
+			if character == '\n' {
+				lineStarts = append(lineStarts, i+1)
+			}
+		}
+		spans := make([][2]int, len(nodes))
+		values := make([][]byte, len(nodes))
+		for i, node := range nodes {
+			span, err := yamlScalarSpan(content, lineStarts, node)
+			if err != nil {
+				return nil, 0, err
+			}
+			spans[i] = span
+			values[i] = []byte(formatYAMLScalar(node.Style, replace(node.Value)))
+		}
+		return replaceSpans(content, spans, values), len(nodes), nil
+	}
+}
+
+func findYAMLNodes(node *yaml.Node, path []selectorSegment, selector []selectorSegment) []*yaml.Node {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		var nodes []*yaml.Node
+		for _, child := range node.Content {
+			nodes = append(nodes, findYAMLNodes(child, path, selector)...)
+		}
+		return nodes
+	case yaml.MappingNode:
+		var nodes []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := appendSegment(path, selectorSegment{key: node.Content[i].Value})
+			nodes = append(nodes, findYAMLNodes(node.Content[i+1], childPath, selector)...)
+		}
+		return nodes
+	case yaml.SequenceNode:
+		var nodes []*yaml.Node
+		for i, child := range node.Content {
+			childPath := appendSegment(path, selectorSegment{index: i, isIndex: true})
+			nodes = append(nodes, findYAMLNodes(child, childPath, selector)...)
+		}
+		return nodes
+	case yaml.ScalarNode:
+		if selectorEquals(path, selector) {
+			return []*yaml.Node{node}
+		}
+	}
+	return nil
+}
+
+// Returns byte span of the scalar in the source document, including quotes.
+func yamlScalarSpan(content []byte, lineStarts []int, node *yaml.Node) ([2]int, error) {
+	if node.Line < 1 || node.Line > len(lineStarts) {
+		return [2]int{}, fmt.Errorf("cannot locate YAML value %q", node.Value)
+	}
+	start := lineStarts[node.Line-1]
+	for column := 1; column < node.Column && start < len(content); column++ {
+		_, width := utf8.DecodeRune(content[start:])
+		start += width
+	}
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for end := start + 1; end < len(content); end++ {
+			switch content[end] {
+			case '\\':
+				end++
+			case '"':
+				return [2]int{start, end + 1}, nil
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for end := start + 1; end < len(content); end++ {
+			if content[end] == '\'' {
+				if end+1 < len(content) && content[end+1] == '\'' {
+					end++
+					continue
+				}
+				return [2]int{start, end + 1}, nil
+			}
+		}
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0:
+		if bytes.HasPrefix(content[start:], []byte(node.Value)) {
+			return [2]int{start, start + len(node.Value)}, nil
+		}
+	}
+	return [2]int{}, fmt.Errorf("cannot update YAML value %q at line %d: only single line scalars are supported", node.Value, node.Line)
+}
+
+func formatYAMLScalar(style yaml.Style, value string) string {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		return strconv.Quote(value)
+	case style&yaml.SingleQuotedStyle != 0:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	default:
+		return value
+	}
+}
+
+// Returns scalar values of all image keys of Kubernetes manifest referring to given image repository.
+func findYAMLImages(node *yaml.Node, repository string) []*yaml.Node {
+	var nodes []*yaml.Node
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			value := node.Content[i+1]
+			if child.Value == "image" && value.Kind == yaml.ScalarNode {
+				if imageRepository, _, ok := splitImage(value.Value); ok && imageRepository == repository {
+					nodes = append(nodes, value)
+				}
+			}
+			continue
+		}
+		nodes = append(nodes, findYAMLImages(child, repository)...)
+	}
+	return nodes
+}
+
+// Splits image reference like registry:5000/org/app:1.2.3 into repository and tag. Images without tag and images
+// pinned by digest are not matched.
+func splitImage(image string) (string, string, bool) {
+	if strings.Contains(image, "@") {
+		return "", "", false
+	}
+	colonIndex := strings.LastIndex(image, ":")
+	if colonIndex < 0 || strings.Contains(image[colonIndex:], "/") {
+		return "", "", false
+	}
+	return image[:colonIndex], image[colonIndex+1:], true
+}
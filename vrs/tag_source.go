@@ -0,0 +1,70 @@
+package vrs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Version of the project is read from its config file.
+	ConfigVersionSource = "config"
+	// Version of the project is read from the last release tag reachable from HEAD. Config file caches the version.
+	TagVersionSource = "tag"
+)
+
+// Placeholder of the version used to find the version in the rendered tag template.
+const tagVersionPlaceholder = "\x00"
+
+// Replaces version of the config with the version of the last release tag reachable from HEAD, if the version is
+// sourced from tags. Version cached in the config is kept if there is no release tag yet.
+func (config *VrsConfig) readTagVersion(executor *executor, gitOptions *GitOptions) error {
+	switch config.VersionSource {
+	case "", ConfigVersionSource:
+		return nil
+	case TagVersionSource:
+	default:
+		return fmt.Errorf("unknown version source %q (expected %s or %s)", config.VersionSource, ConfigVersionSource, TagVersionSource)
+	}
+
+	pattern, err := config.tagPattern(executor, gitOptions)
+	if err != nil {
+		return err
+	}
+	tag, err := executor.git.LastTag(executor.ctx, "", pattern)
+	if err != nil {
+		return fmt.Errorf("cannot read last release tag: %w", err)
+	}
+	if tag == "" {
+		return nil
+	}
+	rendered, err := renderTemplate("tag", config.tagTemplate(gitOptions), config.templateData(executor, tagVersionPlaceholder))
+	if err != nil {
+		return err
+	}
+	placeholderIndex := strings.Index(rendered, tagVersionPlaceholder)
+	if placeholderIndex < 0 {
+		return fmt.Errorf("version can't be read from tags, as tag template %s doesn't contain the version", config.tagTemplate(gitOptions))
+	}
+	prefix, suffix := rendered[:placeholderIndex], rendered[placeholderIndex+len(tagVersionPlaceholder):]
+	if !strings.HasPrefix(tag, prefix) || !strings.HasSuffix(tag, suffix) || len(tag) <= len(prefix)+len(suffix) {
+		return fmt.Errorf("tag %s doesn't match tag template %s", tag, config.tagTemplate(gitOptions))
+	}
+	version := tag[len(prefix) : len(tag)-len(suffix)]
+	err = config.validateVersion(version)
+	if err != nil {
+		return fmt.Errorf("cannot read version from tag %s: %w", tag, err)
+	}
+	config.Version = version
+	return config.parseVersion()
+}
+
+// Returns the current version of the config, read from the last release tag if the version is sourced from tags.
+func (config *VrsConfig) currentVersion(ctx context.Context, basedir string, gitClient GitClient, fileSystem FileSystem) (string, error) {
+	executor := newExecutor(ctx, basedir, false, nil, gitClient, fileSystem, nil)
+	err := config.readTagVersion(executor, nil)
+	if err != nil {
+		return "", err
+	}
+	return config.Version, nil
+}
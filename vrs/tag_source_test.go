@@ -0,0 +1,52 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBumpVersionSourcedFromTags(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", VersionSource: vrs.TagVersionSource})
+	gitOutput(t, basedir, "tag", "v1.4.0")
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0", result.OldVersion)
+	assert.Equal(t, "1.5.0", result.NewVersion)
+	assert.Equal(t, "v1.5.0", result.Tag)
+	config, err := vrs.ParseVersioonConfig(basedir)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", config.Version)
+}
+
+func TestReadCurrentVersionFromTag(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", VersionSource: vrs.TagVersionSource,
+		Tag: &vrs.Tag{Template: "release-{{.Version}}-final"}})
+	gitOutput(t, basedir, "tag", "release-2.1.0-final")
+	gitOutput(t, basedir, "tag", "v3.0.0")
+
+	// When
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "2.1.0", version)
+}
+
+func TestReadCachedVersionWithoutTags(t *testing.T) {
+	// Given
+	basedir := newGitProject(t, &vrs.VrsConfig{Version: "1.0.0", VersionSource: vrs.TagVersionSource})
+
+	// When
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
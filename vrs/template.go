@@ -0,0 +1,90 @@
+package vrs
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Variables shared by templates of commit messages, tags and synced files. Hooks get them as environment variables.
+type templateData struct {
+	// Version rendered by the template. Templates of synced files render both the old and the new version, other
+	// templates render the new version.
+	Version string
+	// Segments of semantic or numeric Version. Zero values are used for other versioning schemes.
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	// All the segments of numeric Version, for example [1 2 3 4] for 1.2.3.4.
+	Segments   []int
+	OldVersion string
+	NewVersion string
+	// Release date in YYYY-MM-DD format.
+	Date string
+	// SHA of the commit the version is bumped at. Empty outside of git repository.
+	CommitSHA string
+	// Abbreviated CommitSHA, like the one printed by git log --oneline.
+	ShortSHA string
+	Profiles []string
+	// Name of the bumped component of the project. Available in templates of tags and commit messages only.
+	Component string
+}
+
+// Versions of the bump executed by the executor.
+type bumpVariables struct {
+	oldVersion string
+	newVersion string
+	profiles   []string
+	commitSHA  string
+}
+
+// Records versions of the bump, so they are available to templates and hooks.
+func (executor *executor) startBump(oldVersion string, newVersion string, profiles []string) {
+	// Projects don't have to be versioned with git, so SHA is optional.
+	commitSHA, _ := executor.git.Head(executor.ctx)
+	executor.bump = &bumpVariables{oldVersion: oldVersion, newVersion: newVersion, profiles: profiles, commitSHA: commitSHA}
+}
+
+// Returns template variables rendering given version. Outside of bump the new version is the rendered one.
+func (executor *executor) templateData(version string) *templateData {
+	data := &templateData{Version: version, NewVersion: version, Date: time.Now().Format("2006-01-02"), Profiles: []string{}}
+	if parsedVersion, err := ParseVersion(version); err == nil {
+		data.Major, data.Minor, data.Patch, data.Prerelease = parsedVersion.Major, parsedVersion.Minor, parsedVersion.Patch, parsedVersion.Prerelease
+	} else if segments, err := parseNumericVersion(version); err == nil {
+		data.Segments = segments
+		padded := append(segments, 0, 0)
+		data.Major, data.Minor, data.Patch = padded[0], padded[1], padded[2]
+	}
+	if bump := executor.bump; bump != nil {
+		data.OldVersion, data.NewVersion, data.CommitSHA = bump.oldVersion, bump.newVersion, bump.commitSHA
+		data.ShortSHA = shortSHA(bump.commitSHA)
+		if bump.profiles != nil {
+			data.Profiles = bump.profiles
+		}
+	}
+	return data
+}
+
+// Returns template variables of the new version as environment variables, for example NEW_VERSION=1.2.0.
+func (data *templateData) environment() []string {
+	return []string{
+		"OLD_VERSION=" + data.OldVersion,
+		"NEW_VERSION=" + data.NewVersion,
+		"MAJOR=" + strconv.Itoa(data.Major),
+		"MINOR=" + strconv.Itoa(data.Minor),
+		"PATCH=" + strconv.Itoa(data.Patch),
+		"PRERELEASE=" + data.Prerelease,
+		"DATE=" + data.Date,
+		"COMMIT_SHA=" + data.CommitSHA,
+		"SHORT_SHA=" + data.ShortSHA,
+		"PROFILES=" + strings.Join(data.Profiles, ","),
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
@@ -0,0 +1,47 @@
+package vrs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Returns path of the user config file providing defaults of all the projects: $XDG_CONFIG_HOME/ver/config.yml,
+// ~/.config/ver/config.yml if the variable is not set.
+func UserConfigFile() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "ver", "config.yml"), nil
+}
+
+// Returns path of the user config file used by default options. Empty path is returned if home directory is unknown.
+func defaultUserConfigFile() string {
+	file, err := UserConfigFile()
+	if err != nil {
+		return ""
+	}
+	return file
+}
+
+// Reads user config file from the local disk. Nil is returned if the path is empty or the file doesn't exist. Settings
+// specific to a single project, like the version or components, are ignored.
+func readUserConfig(file string) (*VrsConfig, error) {
+	if file == "" {
+		return nil, nil
+	}
+	config, err := readConfigFile(&OSFileSystem{}, file, map[string]bool{})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read user config %s: %w", file, err)
+	}
+	config.Version, config.Components = "", nil
+	return config, nil
+}
@@ -0,0 +1,86 @@
+package vrs_test
+
+import (
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Points user config to empty directory, so the config of the user running the tests doesn't affect them.
+func TestMain(m *testing.M) {
+	configHome, err := ioutil.TempDir("", "ver-config-*")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_CONFIG_HOME", configHome)
+	code := m.Run()
+	_ = os.RemoveAll(configHome)
+	os.Exit(code)
+}
+
+func writeUserConfig(t *testing.T, config string) string {
+	file := filepath.Join(t.TempDir(), "config.yml")
+	assert.NoError(t, ioutil.WriteFile(file, []byte(config), 0644))
+	return file
+}
+
+func TestBumpWithUserConfigDefaults(t *testing.T) {
+	// Given
+	userConfigFile := writeUserConfig(t, "version: 9.9.9\ncommitMessage: 'chore: release {{.Version}}'\npush:\n  disabled: true\n")
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.0.0\ntag:\n  template: release-{{.Version}}\n")})
+	git := &recordingGitClient{}
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", GitCommit: true, GitPush: true, FileSystem: fileSystem,
+		UserConfigFile: userConfigFile, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.NewVersion)
+	assert.Equal(t, []string{"add", "commit chore: release 1.1.0", "tag release-1.1.0"}, git.calls)
+	assert.Equal(t, "version: 1.1.0\ntag:\n  template: release-{{.Version}}\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestUserConfigFileFollowsXdgConfigHome(t *testing.T) {
+	// Given
+	setenv(t, "XDG_CONFIG_HOME", "/etc/xdg")
+
+	// When
+	file, err := vrs.UserConfigFile()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/xdg/ver/config.yml", file)
+}
+
+func TestIgnoreUserConfigNotSelectedInOptions(t *testing.T) {
+	// Given
+	configHome := t.TempDir()
+	setenv(t, "XDG_CONFIG_HOME", configHome)
+	assert.NoError(t, os.MkdirAll(filepath.Join(configHome, "ver"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(configHome, "ver", "config.yml"), []byte("commitMessage: Release.\n"), 0644))
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.0.0\n")})
+	git := &recordingGitClient{}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", GitCommit: true, FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"add", "commit Version bump.", "tag v1.1.0"}, git.calls)
+}
+
+func TestDefaultOptionsSelectUserConfig(t *testing.T) {
+	// Given
+	setenv(t, "XDG_CONFIG_HOME", "/etc/xdg")
+
+	// When
+	options, err := vrs.NewDefaultBumpOptions()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/xdg/ver/config.yml", options.UserConfigFile)
+}
@@ -0,0 +1,148 @@
+package vrs
+
+import (
+	"fmt"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Problem found in the config file. Line is zero if the problem is not related to a single line of the file.
+type ConfigProblem struct {
+	Line    int
+	Message string
+}
+
+func (problem ConfigProblem) String() string {
+	if problem.Line == 0 {
+		return problem.Message
+	}
+	return fmt.Sprintf("line %d: %s", problem.Line, problem.Message)
+}
+
+type ValidateConfigOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file of
+	// ConfigFileNames.
+	ConfigPath string
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+}
+
+func NewDefaultValidateConfigOptions() (*ValidateConfigOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &ValidateConfigOptions{Basedir: basedir}, nil
+}
+
+var problemLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Validates the config file of the project, reporting unknown keys, values of wrong type, invalid regular expressions
+// and versions. Unlike parsing of the config, validation doesn't stop on the first problem. Error is returned only if
+// the config file cannot be read.
+func ValidateConfig(options *ValidateConfigOptions) ([]ConfigProblem, error) {
+	if options == nil {
+		o, err := NewDefaultValidateConfigOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+	_, yml, err := readProjectConfigFile(fileSystemOrDefault(options.FileSystem), options.Basedir, options.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &yaml.Node{}
+	err = yaml.Unmarshal(yml, document)
+	if err != nil {
+		return []ConfigProblem{parseProblem(err.Error())}, nil
+	}
+	var problems []ConfigProblem
+	config := &VrsConfig{}
+	err = yamlv2.UnmarshalStrict(yml, config)
+	if typeErr, ok := err.(*yamlv2.TypeError); ok {
+		for _, message := range typeErr.Errors {
+			problems = append(problems, parseProblem(message))
+		}
+	} else if err != nil {
+		problems = append(problems, parseProblem(err.Error()))
+	}
+	problems = append(problems, patternProblems(document)...)
+	if len(document.Content) > 0 {
+		_, err = migrateConfig(yml)
+		if configVersion := mappingValue(document.Content[0], "configVersion"); err != nil && configVersion != nil {
+			problems = append(problems, ConfigProblem{Line: configVersion.Line, Message: err.Error()})
+		}
+		version := mappingValue(document.Content[0], "version")
+		if version == nil && config.Extends == "" && len(config.Components) == 0 {
+			problems = append(problems, ConfigProblem{Message: "version is missing"})
+		} else if version != nil && (version.Value != "" || len(config.Components) == 0) {
+			err = config.validateVersion(config.bareVersion(version.Value))
+			if err != nil {
+				problems = append(problems, ConfigProblem{Line: version.Line, Message: err.Error()})
+			}
+		}
+		for _, component := range config.Components {
+			if component == nil || component.Path != "" {
+				continue
+			}
+			problem := ConfigProblem{}
+			if version := componentNode(document.Content[0], component.Name, "version"); version != nil {
+				problem.Line = version.Line
+			}
+			err = config.validateVersion(config.bareVersion(component.Version))
+			if err != nil {
+				problem.Message = fmt.Sprintf("invalid version of component %s: %s", component.Name, err)
+				problems = append(problems, problem)
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return problems, nil
+}
+
+func parseProblem(message string) ConfigProblem {
+	groups := problemLine.FindStringSubmatch(message)
+	if groups == nil {
+		return ConfigProblem{Message: message}
+	}
+	line, _ := strconv.Atoi(groups[1])
+	return ConfigProblem{Line: line, Message: groups[2]}
+}
+
+// Returns problems of the regular expressions given as pattern values anywhere in the document.
+func patternProblems(node *yaml.Node) []ConfigProblem {
+	var problems []ConfigProblem
+	if node.Kind == yaml.MappingNode {
+		pattern := mappingValue(node, "pattern")
+		if pattern != nil && pattern.Kind == yaml.ScalarNode {
+			_, err := regexp.Compile(pattern.Value)
+			if err != nil {
+				problems = append(problems, ConfigProblem{Line: pattern.Line, Message: fmt.Sprintf("invalid pattern: %s", err)})
+			}
+		}
+	}
+	for _, child := range node.Content {
+		problems = append(problems, patternProblems(child)...)
+	}
+	return problems
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package vrs_test
+
+import (
+	"encoding/json"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.0\ntag:\n  anotated: true\n" +
+		"sync:\n  files:\n  - name: VERSION\n    pattern: '(\\d+'\n    maxReplacements: many\n")})
+
+	// When
+	problems, err := vrs.ValidateConfig(&vrs.ValidateConfigOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, problems, 4)
+	assert.Equal(t, 1, problems[0].Line)
+	assert.Equal(t, "line 3: field anotated not found in type vrs.Tag", problems[1].String())
+	assert.Equal(t, 7, problems[2].Line)
+	assert.Contains(t, problems[2].Message, "invalid pattern")
+	assert.Equal(t, 8, problems[3].Line)
+}
+
+func TestValidateComponentVersions(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("components:\n- name: api\n  version: 1.0.0\n" +
+		"- name: web\n  version: 2.x.0\n")})
+
+	// When
+	problems, err := vrs.ValidateConfig(&vrs.ValidateConfigOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, problems, 1)
+	assert.Equal(t, 5, problems[0].Line)
+	assert.Contains(t, problems[0].Message, "invalid version of component web")
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte("version: 1.0.0\nsync:\n  files:\n  - name: VERSION\n")})
+
+	// When
+	problems, err := vrs.ValidateConfig(&vrs.ValidateConfigOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestPublishedSchemaIsUpToDate(t *testing.T) {
+	// Given
+	published, err := ioutil.ReadFile("../vrs.schema.json")
+	assert.NoError(t, err)
+
+	// When
+	schema, err := vrs.ConfigSchema()
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, string(schema)+"\n", string(published), "regenerate vrs.schema.json with vrs schema > vrs.schema.json")
+	assert.True(t, json.Valid(schema))
+}
@@ -0,0 +1,304 @@
+package vrs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const DefaultPrereleaseIdentifier = "rc"
+
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Metadata   string
+}
+
+func ParseVersion(version string) (*Version, error) {
+	coreVersion, prerelease, metadata := version, "", ""
+	if plusIndex := strings.Index(coreVersion, "+"); plusIndex >= 0 {
+		coreVersion, metadata = coreVersion[:plusIndex], coreVersion[plusIndex+1:]
+		err := validateMetadata(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidVersion, version, err)
+		}
+	}
+	if hyphenIndex := strings.Index(coreVersion, "-"); hyphenIndex >= 0 {
+		coreVersion, prerelease = coreVersion[:hyphenIndex], coreVersion[hyphenIndex+1:]
+		err := validatePrerelease(prerelease)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidVersion, version, err)
+		}
+	}
+
+	versionParts := strings.Split(coreVersion, ".")
+	if len(versionParts) != 3 {
+		return nil, fmt.Errorf("%w %q: expected major.minor.patch format", ErrInvalidVersion, version)
+	}
+	numbers := make([]int, len(versionParts))
+	for i, part := range versionParts {
+		number, err := parseVersionNumber(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidVersion, version, err)
+		}
+		numbers[i] = number
+	}
+	return &Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Prerelease: prerelease, Metadata: metadata}, nil
+}
+
+func validateMetadata(metadata string) error {
+	for _, identifier := range strings.Split(metadata, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty build metadata identifier")
+		}
+		for _, character := range identifier {
+			if !isAlphanumeric(character) && character != '-' {
+				return fmt.Errorf("build metadata identifier %q contains invalid character %q", identifier, character)
+			}
+		}
+	}
+	return nil
+}
+
+func validatePrerelease(prerelease string) error {
+	for _, identifier := range strings.Split(prerelease, ".") {
+		if identifier == "" {
+			return fmt.Errorf("empty prerelease identifier")
+		}
+		for _, character := range identifier {
+			if !isAlphanumeric(character) && character != '-' {
+				return fmt.Errorf("prerelease identifier %q contains invalid character %q", identifier, character)
+			}
+		}
+		if isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("prerelease identifier %q has leading zero", identifier)
+		}
+	}
+	return nil
+}
+
+func parseVersionNumber(number string) (int, error) {
+	if number == "" {
+		return 0, fmt.Errorf("empty version segment")
+	}
+	if !isNumeric(number) {
+		return 0, fmt.Errorf("version segment %q is not a number", number)
+	}
+	if len(number) > 1 && number[0] == '0' {
+		return 0, fmt.Errorf("version segment %q has leading zero", number)
+	}
+	return strconv.Atoi(number)
+}
+
+// Compares versions according to semantic versioning precedence rules. Build metadata is ignored.
+func (version *Version) Compare(other *Version) int {
+	if version.Major != other.Major {
+		return compareNumbers(version.Major, other.Major)
+	}
+	if version.Minor != other.Minor {
+		return compareNumbers(version.Minor, other.Minor)
+	}
+	if version.Patch != other.Patch {
+		return compareNumbers(version.Patch, other.Patch)
+	}
+	return comparePrereleases(version.Prerelease, other.Prerelease)
+}
+
+// Compares versions according to semantic versioning precedence rules, returning -1 if a is older than b, 0 if they
+// are equal and 1 if a is newer. Versions might be prefixed with v. Versions which are not semantic, but consist of
+// dot-separated numbers only (1.2.3.4), are compared segment by segment.
+func Compare(a string, b string) (int, error) {
+	_, a = splitVersionPrefix(a)
+	_, b = splitVersionPrefix(b)
+	aVersion, aErr := ParseVersion(a)
+	bVersion, bErr := ParseVersion(b)
+	if aErr == nil && bErr == nil {
+		return aVersion.Compare(bVersion), nil
+	}
+	aSegments, err := parseNumericVersion(a)
+	if err != nil {
+		return 0, firstError(aErr, bErr)
+	}
+	bSegments, err := parseNumericVersion(b)
+	if err != nil {
+		return 0, firstError(aErr, bErr)
+	}
+	for i := 0; i < len(aSegments) || i < len(bSegments); i++ {
+		aSegment, bSegment := 0, 0
+		if i < len(aSegments) {
+			aSegment = aSegments[i]
+		}
+		if i < len(bSegments) {
+			bSegment = bSegments[i]
+		}
+		if aSegment != bSegment {
+			return compareNumbers(aSegment, bSegment), nil
+		}
+	}
+	return 0, nil
+}
+
+// Sorts versions from the oldest to the newest according to the precedence used by Compare. Versions of the same
+// precedence (1.2.0 and v1.2.0+build.1) keep their order. Slice is left unchanged if any of the versions is invalid.
+func Sort(versions []string) error {
+	for _, version := range versions {
+		_, err := Compare(version, version)
+		if err != nil {
+			return err
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		result, _ := Compare(versions[i], versions[j])
+		return result < 0
+	})
+	return nil
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compares prereleases according to semantic versioning precedence rules. Version without prerelease has higher
+// precedence than any of its prereleases.
+func comparePrereleases(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	aIdentifiers, bIdentifiers := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aIdentifier, bIdentifier := aIdentifiers[i], bIdentifiers[i]
+		if aIdentifier == bIdentifier {
+			continue
+		}
+		aNumeric, bNumeric := isNumeric(aIdentifier), isNumeric(bIdentifier)
+		switch {
+		case aNumeric && bNumeric:
+			aNumber, _ := strconv.Atoi(aIdentifier)
+			bNumber, _ := strconv.Atoi(bIdentifier)
+			return compareNumbers(aNumber, bNumber)
+		case aNumeric:
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			return strings.Compare(aIdentifier, bIdentifier)
+		}
+	}
+	return compareNumbers(len(aIdentifiers), len(bIdentifiers))
+}
+
+func (version *Version) Equal(other *Version) bool {
+	return version.Compare(other) == 0
+}
+
+func (version *Version) String() string {
+	versionString := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	if version.Prerelease != "" {
+		versionString += "-" + version.Prerelease
+	}
+	if version.Metadata != "" {
+		versionString += "+" + version.Metadata
+	}
+	return versionString
+}
+
+func (version *Version) WithoutMetadata() *Version {
+	return &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch, Prerelease: version.Prerelease}
+}
+
+func stripMetadata(version string) string {
+	if plusIndex := strings.Index(version, "+"); plusIndex >= 0 {
+		return version[:plusIndex]
+	}
+	return version
+}
+
+// Returns the next version for given segment. Prerelease of the version being bumped is released if the bumped
+// segment is the lowest non-zero one already, for example minor bump of 1.4.0-rc.1 gives 1.4.0 and not 1.5.0.
+func (version *Version) Bump(kind BumpKind) (*Version, error) {
+	release := &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}
+	switch kind {
+	case MajorSegment:
+		if version.Prerelease != "" && version.Minor == 0 && version.Patch == 0 {
+			return release, nil
+		}
+		return &Version{Major: version.Major + 1}, nil
+	case MinorSegment, "":
+		if version.Prerelease != "" && version.Patch == 0 {
+			return release, nil
+		}
+		return &Version{Major: version.Major, Minor: version.Minor + 1}, nil
+	case PatchSegment:
+		if version.Prerelease != "" {
+			return release, nil
+		}
+		return &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1}, nil
+	case PrereleaseSegment:
+		return version.BumpPrerelease(""), nil
+	default:
+		return nil, fmt.Errorf("unknown version segment %q (expected %s, %s, %s or %s)", kind, MajorSegment, MinorSegment, PatchSegment, PrereleaseSegment)
+	}
+}
+
+// Increments prerelease counter of the version, i.e. 1.4.0-rc.2 becomes 1.4.0-rc.3. If the version has no prerelease
+// yet, the patch segment is bumped and the new prerelease counter is started (1.4.0 becomes 1.4.1-rc.1). Switching to
+// another identifier restarts the counter (1.4.0-alpha.3 becomes 1.4.0-beta.1). Empty identifier keeps the current
+// one or falls back to DefaultPrereleaseIdentifier.
+func (version *Version) BumpPrerelease(identifier string) *Version {
+	if version.Prerelease == "" {
+		if identifier == "" {
+			identifier = DefaultPrereleaseIdentifier
+		}
+		return &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1, Prerelease: identifier + ".1"}
+	}
+
+	currentIdentifier, counter := version.Prerelease, 0
+	if dotIndex := strings.LastIndex(version.Prerelease, "."); dotIndex >= 0 && isNumeric(version.Prerelease[dotIndex+1:]) {
+		currentIdentifier = version.Prerelease[:dotIndex]
+		counter, _ = strconv.Atoi(version.Prerelease[dotIndex+1:])
+	}
+	if identifier != "" && identifier != currentIdentifier {
+		currentIdentifier, counter = identifier, 0
+	}
+	return &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch, Prerelease: fmt.Sprintf("%s.%d", currentIdentifier, counter+1)}
+}
+
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, character := range value {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(character rune) bool {
+	return (character >= '0' && character <= '9') || (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+func compareNumbers(a int, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
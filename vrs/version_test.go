@@ -0,0 +1,228 @@
+package vrs_test
+
+import (
+	"errors"
+	"github.com/hekonsek/vrs/vrs"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	// When
+	version, err := vrs.ParseVersion("1.20.3")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &vrs.Version{Major: 1, Minor: 20, Patch: 3}, version)
+	assert.Equal(t, "1.20.3", version.String())
+}
+
+func TestParseInvalidVersion(t *testing.T) {
+	for _, version := range []string{"", "1", "1.2", "1.2.3.4", "1.x.3", "1.-2.3", "01.2.3"} {
+		// When
+		_, err := vrs.ParseVersion(version)
+
+		// Then
+		assert.True(t, errors.Is(err, vrs.ErrInvalidVersion), version)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	// Given
+	older, err := vrs.ParseVersion("1.9.9")
+	assert.NoError(t, err)
+	newer, err := vrs.ParseVersion("1.10.0")
+	assert.NoError(t, err)
+
+	// Then
+	assert.Equal(t, -1, older.Compare(newer))
+	assert.Equal(t, 1, newer.Compare(older))
+	assert.Equal(t, 0, older.Compare(older))
+	assert.True(t, older.Equal(&vrs.Version{Major: 1, Minor: 9, Patch: 9}))
+	assert.False(t, older.Equal(newer))
+}
+
+func TestParseConfigWithInvalidVersion(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, vrs.VrsConfigFileName), []byte("version: foo\n"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.ParseVersioonConfig(basedir)
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+}
+
+func TestParseConfigWithParsedVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{vrs.VrsConfigFileName: []byte("version: 1.4.0-rc.2\n")})
+
+	// When
+	config, err := vrs.ParseVersioonConfigFS(fileSystem, ".")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &vrs.Version{Major: 1, Minor: 4, Patch: 0, Prerelease: "rc.2"}, config.ParsedVersion())
+}
+
+func TestParsePrereleaseVersion(t *testing.T) {
+	// When
+	version, err := vrs.ParseVersion("1.4.0-rc.2")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &vrs.Version{Major: 1, Minor: 4, Patch: 0, Prerelease: "rc.2"}, version)
+	assert.Equal(t, "1.4.0-rc.2", version.String())
+}
+
+func TestComparePrereleaseVersions(t *testing.T) {
+	// Given
+	versions := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}
+
+	for i := 0; i < len(versions)-1; i++ {
+		older, err := vrs.ParseVersion(versions[i])
+		assert.NoError(t, err)
+		newer, err := vrs.ParseVersion(versions[i+1])
+		assert.NoError(t, err)
+
+		// Then
+		assert.Equal(t, -1, older.Compare(newer), versions[i])
+		assert.Equal(t, 1, newer.Compare(older), versions[i])
+	}
+}
+
+func TestBumpPrerelease(t *testing.T) {
+	for version, expected := range map[string]string{
+		"1.4.0":         "1.4.1-rc.1",
+		"1.4.0-rc.2":    "1.4.0-rc.3",
+		"1.4.0-beta":    "1.4.0-beta.1",
+		"1.4.0-alpha.3": "1.4.0-alpha.4",
+	} {
+		// Given
+		parsedVersion, err := vrs.ParseVersion(version)
+		assert.NoError(t, err)
+
+		// When
+		bumpedVersion, err := parsedVersion.Bump(vrs.PrereleaseSegment)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, bumpedVersion.String())
+	}
+}
+
+func TestBumpReleasesPrerelease(t *testing.T) {
+	for _, testCase := range []struct {
+		version  string
+		kind     vrs.BumpKind
+		expected string
+	}{
+		{"1.4.0-rc.1", vrs.MinorSegment, "1.4.0"},
+		{"1.4.1-rc.1", vrs.PatchSegment, "1.4.1"},
+		{"2.0.0-rc.1", vrs.MajorSegment, "2.0.0"},
+		{"1.4.1-rc.1", vrs.MinorSegment, "1.5.0"},
+		{"2.1.0-rc.1", vrs.MajorSegment, "3.0.0"},
+	} {
+		// Given
+		parsedVersion, err := vrs.ParseVersion(testCase.version)
+		assert.NoError(t, err)
+
+		// When
+		bumpedVersion, err := parsedVersion.Bump(testCase.kind)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, bumpedVersion.String())
+	}
+}
+
+func TestBumpPrereleaseWithNewIdentifier(t *testing.T) {
+	// Given
+	version, err := vrs.ParseVersion("1.4.0-alpha.3")
+	assert.NoError(t, err)
+
+	// When
+	bumpedVersion := version.BumpPrerelease("beta")
+
+	// Then
+	assert.Equal(t, "1.4.0-beta.1", bumpedVersion.String())
+}
+
+func TestParseVersionWithMetadata(t *testing.T) {
+	// When
+	version, err := vrs.ParseVersion("1.2.3-rc.1+20240101.sha.abcdef")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, &vrs.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Metadata: "20240101.sha.abcdef"}, version)
+	assert.Equal(t, "1.2.3-rc.1+20240101.sha.abcdef", version.String())
+	assert.Equal(t, "1.2.3-rc.1", version.WithoutMetadata().String())
+}
+
+func TestCompareIgnoresMetadata(t *testing.T) {
+	// Given
+	first, err := vrs.ParseVersion("1.2.3+build.1")
+	assert.NoError(t, err)
+	second, err := vrs.ParseVersion("1.2.3+build.2")
+	assert.NoError(t, err)
+
+	// Then
+	assert.True(t, first.Equal(second))
+}
+
+func TestCompareVersionStrings(t *testing.T) {
+	for _, comparison := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.10.0", "1.9.0", 1},
+		{"v1.2.0", "1.2.0", 0},
+		{"1.2.0-rc.1", "1.2.0", -1},
+		{"1.2.3.4", "1.2.3", 1},
+		{"1.2", "1.2.0.0", 0},
+	} {
+		// When
+		result, err := vrs.Compare(comparison.a, comparison.b)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, comparison.expected, result, comparison.a+" "+comparison.b)
+	}
+}
+
+func TestCompareInvalidVersionString(t *testing.T) {
+	// When
+	_, err := vrs.Compare("1.2.0", "latest")
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+}
+
+func TestSortVersions(t *testing.T) {
+	// Given
+	versions := []string{"v1.10.0", "1.2.0", "1.2.0-rc.1", "v0.9.1", "1.2.0-alpha", "1.2.0+build.1"}
+
+	// When
+	err := vrs.Sort(versions)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v0.9.1", "1.2.0-alpha", "1.2.0-rc.1", "1.2.0", "1.2.0+build.1", "v1.10.0"}, versions)
+}
+
+func TestSortInvalidVersions(t *testing.T) {
+	// Given
+	versions := []string{"1.2.0", "latest", "1.0.0"}
+
+	// When
+	err := vrs.Sort(versions)
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrInvalidVersion))
+	assert.Equal(t, []string{"1.2.0", "latest", "1.0.0"}, versions)
+}
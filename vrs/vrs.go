@@ -1,56 +1,218 @@
 package vrs
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"gopkg.in/yaml.v2"
+	"io"
 	"os"
-	"os/exec"
 	"path"
-	"regexp"
+	"runtime"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 )
 
 const VrsConfigFileName = "vrs.yml"
 
+// Names of the config file looked up in the project directory, in order of precedence.
+var ConfigFileNames = []string{VrsConfigFileName, ".vrs.yml", "ver.yaml"}
+
+// Permissions of files created by vrs, unless configured otherwise.
+const DefaultFileMode os.FileMode = 0644
+
 type VrsConfig struct {
-	Version  string
-	Sync     *Sync      `yaml:",omitempty"`
-	Profiles []*Profile `yaml:",omitempty"`
+	// Version of the config format. Configs of older versions are migrated when read. See CurrentConfigVersion.
+	ConfigVersion int `yaml:"configVersion,omitempty"`
+	// Path of the config this one inherits settings from, relative to the directory of this config.
+	Extends string `yaml:",omitempty"`
+	Version string `yaml:",omitempty"`
+	Scheme  string `yaml:",omitempty"`
+	// Format of calendar versions, or custom format of versions of the default scheme, for example
+	// {major}.{minor}.{patch}-{channel}.
+	Format string `yaml:",omitempty"`
+	// Number of segments of versions of the numeric scheme, for example 4 for 1.2.3.4.
+	Segments      int        `yaml:",omitempty"`
+	Metadata      *Metadata  `yaml:",omitempty"`
+	Tag           *Tag       `yaml:",omitempty"`
+	Commit        *Commit    `yaml:",omitempty"`
+	CommitMessage string     `yaml:"commitMessage,omitempty"`
+	Push          *Push      `yaml:",omitempty"`
+	Sync          *Sync      `yaml:",omitempty"`
+	Profiles      []*Profile `yaml:",omitempty"`
+	Changelog     *Changelog `yaml:",omitempty"`
+	Notes         *Notes     `yaml:",omitempty"`
+	GitHub        *GitHub    `yaml:"github,omitempty"`
+	GitLab        *GitLab    `yaml:"gitlab,omitempty"`
+	Gitea         *Gitea     `yaml:"gitea,omitempty"`
+	Hooks         *Hooks     `yaml:",omitempty"`
+	Notify        *Notify    `yaml:",omitempty"`
+	// Number of CI build appended to the version written into sync files.
+	BuildNumber *BuildNumber `yaml:"buildNumber,omitempty"`
+	// Independently versioned parts of the project. Version of the project itself is optional if it has components.
+	Components []*Component `yaml:",omitempty"`
+	// Permissions of files created by vrs in octal notation, for example 0640. Existing files keep their permissions.
+	FileMode string `yaml:"fileMode,omitempty"`
+	// Prefix of the versions written to the config file, for example v. Versions are read with or without the prefix.
+	// Defaults to the prefix of the version declared in the config file. Tags and synced files are prefixed using the
+	// tag template and the replacement of the sync file.
+	VersionPrefix string `yaml:"versionPrefix,omitempty"`
+	// Source of the current version, ConfigVersionSource by default. With TagVersionSource the version is read from
+	// the last release tag and the config file only caches it.
+	VersionSource string `yaml:"versionSource,omitempty"`
+
+	parsedVersion *Version
+	// Path of the config file relative to the project directory. Defaults to VrsConfigFileName.
+	file string
+	// Config stored in the file the version is written to, if it differs from this config: the project config of
+	// inline component or the config of component with path as read from its file.
+	parent *VrsConfig
+	// Component the config has been selected as. Nil for the project itself.
+	component *Component
+	// Components bumped together with this one, because they depend on it.
+	dependents []*dependentBump
+	// Config as declared in its file, if it extends another config or references environment variables. Only the
+	// declared settings are written back.
+	declared *VrsConfig
+	// Prefix of the version declared in the config file, for example v.
+	declaredPrefix string
+}
+
+// Controls whether build metadata of the version (1.2.3+build.5) is written into sync files and git tags. By default
+// metadata is kept in vrs.yml only.
+type Metadata struct {
+	Sync bool
+	Tag  bool
 }
 
 type Sync struct {
 	Files []SyncFile
+	// Glob patterns of files skipped when expanding glob sync file names, for example vendor/** or **/node_modules/**.
+	Exclude []string `yaml:",omitempty"`
 }
 
 type SyncFile struct {
-	Name    string
+	// Path of the file relative to the project directory. Glob patterns like charts/**/Chart.yaml select multiple files.
+	Name string
+	// Regular expression replaced with the new version. Old version is replaced if empty.
 	Pattern string
+	// Type of the file updated structurally, for example json. Plain text replacement is used if empty.
+	Type string `yaml:",omitempty"`
+	// Selector of the value updated in structured file, for example $.version. Key of properties and INI files.
+	Path string `yaml:",omitempty"`
+	// Fails the release if the file contains no occurrence of the version or the pattern.
+	Required bool `yaml:",omitempty"`
+	// Directory synchronized recursively instead of the single file. Can't be combined with Name.
+	Directory string `yaml:",omitempty"`
+	// Glob pattern of names of the files synchronized within Directory, for example *.md. All files are synchronized
+	// by default.
+	Filter string `yaml:",omitempty"`
+	// Keys of Helm chart updated by helm-chart sync, version and appVersion by default.
+	Keys []string `yaml:",omitempty"`
+	// Repository of container image updated by image sync, for example myorg/app.
+	Image string `yaml:",omitempty"`
+	// Section of INI file containing the key selected by Path. Empty section selects keys placed before the first
+	// section.
+	Section string `yaml:",omitempty"`
+	// Go template of the whole content of the file written by plain sync, for example "v{{.Version}}\n".
+	Template string `yaml:",omitempty"`
+	// Go template of the value written instead of the bare version, for example v{{.Version}} or {{.Major}}.{{.Minor}}.
+	// Text sync without pattern replaces the old version rendered with the same template.
+	Replacement string `yaml:",omitempty"`
+	// Component of the version synced instead of the full version, major or major.minor. Requires semantic versioning.
+	Component string `yaml:",omitempty"`
+	// Text written before and after the version, for example v prefix of the version stored in the file.
+	Prefix string `yaml:",omitempty"`
+	Suffix string `yaml:",omitempty"`
+	// Maximum number of occurrences replaced by text sync, counting from the beginning of the file. All occurrences are
+	// replaced if zero.
+	MaxReplacements int `yaml:"maxReplacements,omitempty"`
+	// Replaces the first occurrence only. Shortcut for MaxReplacements equal to 1.
+	First bool `yaml:",omitempty"`
+	// Settings passed to the sync plugin implementing the type of the file.
+	Options map[string]string `yaml:",omitempty"`
+}
+
+// Returns file name or glob pattern selecting the files to synchronize.
+func (file *SyncFile) namePattern() (string, error) {
+	if file.Directory == "" {
+		return file.Name, nil
+	}
+	if file.Name != "" {
+		return "", fmt.Errorf("sync file can't have both name %s and directory %s", file.Name, file.Directory)
+	}
+	return path.Join(file.Directory, "**", firstNonEmpty(file.Filter, "*")), nil
 }
 
 type Profile struct {
 	Name string
 	Sync *Sync
+	// Activates the profile without selecting it explicitly.
+	Default bool `yaml:",omitempty"`
+	// Conditions activating the profile without selecting it explicitly.
+	Activation *Activation `yaml:",omitempty"`
 }
 
 var NoVersioonFileFound = errors.New("no vrs file found")
 
+// Alias of NoVersioonFileFound following the naming of other errors.
+var ErrNoVersioonFile = NoVersioonFileFound
+
 func ParseVersioonConfig(basePath string) (*VrsConfig, error) {
-	versioonConfigPath := path.Join(basePath, VrsConfigFileName)
-	if _, err := os.Stat(versioonConfigPath); err != nil {
-		if os.IsNotExist(err) {
-			return nil, NoVersioonFileFound
+	return ParseVersioonConfigFS(nil, basePath)
+}
+
+// Parses versioon file using given file system. Nil file system means the local disk.
+func ParseVersioonConfigFS(fileSystem FileSystem, basePath string) (*VrsConfig, error) {
+	return ParseVersioonConfigFile(fileSystem, basePath, "")
+}
+
+// Parses given config file of the project. The path is relative to the project directory or absolute. Empty path
+// selects the first existing file of ConfigFileNames. User config is not applied.
+func ParseVersioonConfigFile(fileSystem FileSystem, basePath string, configPath string) (*VrsConfig, error) {
+	return parseConfigFile(fileSystem, basePath, configPath, "")
+}
+
+// Parses given config file of the project with defaults from the user config file. Empty user config path disables
+// the defaults.
+func parseConfigFile(fileSystem FileSystem, basePath string, configPath string, userConfigFile string) (*VrsConfig, error) {
+	fileSystem = fileSystemOrDefault(fileSystem)
+	configPath, err := relativeConfigPath(basePath, configPath)
+	if err != nil {
+		return nil, err
+	}
+	var config *VrsConfig
+	for _, candidate := range configCandidates(configPath) {
+		config, err = readConfigFile(fileSystem, path.Join(basePath, candidate), map[string]bool{})
+		if err == nil {
+			config.file = candidate
+			break
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
 		}
 	}
-
-	yml, err := os.ReadFile(versioonConfigPath)
+	if config == nil {
+		return nil, NoVersioonFileFound
+	}
+	userConfig, err := readUserConfig(userConfigFile)
 	if err != nil {
 		return nil, err
 	}
-
-	config := &VrsConfig{}
-	err = yaml.Unmarshal(yml, config)
+	if userConfig != nil {
+		config = config.inherit(userConfig)
+	}
+	config.normalizeVersions()
+	err = config.validateComponentVersions()
+	if err != nil {
+		return nil, err
+	}
+	if config.Version == "" && len(config.Components) > 0 {
+		return config, nil
+	}
+	err = config.parseVersion()
 	if err != nil {
 		return nil, err
 	}
@@ -58,71 +220,286 @@ func ParseVersioonConfig(basePath string) (*VrsConfig, error) {
 	return config, nil
 }
 
+// Validates versions of the inline components, so malformed version is reported when the config is loaded rather than
+// when the component is bumped. Components with their own config are validated when that config is loaded.
+func (config *VrsConfig) validateComponentVersions() error {
+	for _, component := range config.Components {
+		if component.Path != "" {
+			continue
+		}
+		err := config.validateVersion(component.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version of component %s: %w", component.Name, err)
+		}
+	}
+	return nil
+}
+
+// Returns names of the config file to look up, given the path of the config file selected by the user.
+func configCandidates(configPath string) []string {
+	if configPath != "" {
+		return []string{configPath}
+	}
+	return ConfigFileNames
+}
+
+func (config *VrsConfig) parseVersion() error {
+	err := config.validateVersion(config.Version)
+	if err != nil {
+		return err
+	}
+	if (config.Scheme == "" || config.Scheme == SemVerScheme) && config.Format == "" {
+		config.parsedVersion, err = ParseVersion(config.Version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns version parsed when the config was loaded by ParseVersioonConfig. Nil is returned if the project doesn't use
+// semantic versioning.
+func (config *VrsConfig) ParsedVersion() *Version {
+	return config.parsedVersion
+}
+
+func (config *VrsConfig) validateVersion(version string) error {
+	scheme, err := config.scheme(nil)
+	if err != nil {
+		return err
+	}
+	return scheme.Validate(version)
+}
+
 func (config *VrsConfig) Write(basePath string) error {
-	yml, err := yaml.Marshal(config)
+	return config.WriteFS(nil, basePath)
+}
+
+// Writes versioon file using given file system. Nil file system means the local disk.
+func (config *VrsConfig) WriteFS(fileSystem FileSystem, basePath string) error {
+	fileSystem = fileSystemOrDefault(fileSystem)
+	configPath := path.Join(basePath, config.configFile())
+	originalYml, err := fileSystem.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	yml, err := config.fileContent(originalYml)
+	if err != nil {
+		return err
+	}
+	mode, err := fileSystem.Mode(configPath)
+	if os.IsNotExist(err) {
+		mode, err = config.fileMode()
+	}
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(path.Join(basePath, VrsConfigFileName), yml, 0600)
+	err = fileSystem.WriteFile(configPath, yml, mode)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Returns permissions of files created by vrs.
+func (config *VrsConfig) fileMode() (os.FileMode, error) {
+	if config.FileMode == "" {
+		return DefaultFileMode, nil
+	}
+	mode, err := strconv.ParseUint(config.FileMode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid file mode %q: expected octal permissions like 0644", config.FileMode)
+	}
+	return os.FileMode(mode), nil
+}
+
 func (config *VrsConfig) WriteAndCommit(baseDir string, commit bool, push bool, commitMessage string) error {
-	err := config.Write(baseDir)
+	return config.writeAndCommit(newExecutor(context.Background(), baseDir, false, nil, nil, nil, nil), commit, push, commitMessage, nil, &BumpResult{})
+}
+
+func (config *VrsConfig) writeAndCommit(executor *executor, commit bool, push bool, commitMessage string, gitOptions *GitOptions, result *BumpResult) (err error) {
+	defer func() {
+		if err != nil {
+			err = executor.rollback(err)
+		}
+	}()
+
+	executor.fileMode, err = config.fileMode()
 	if err != nil {
 		return err
 	}
+	push = config.pushEnabled(push)
+	if commit {
+		commit, err = config.gitAvailable(executor, result)
+		if err != nil {
+			return err
+		}
+	}
 
 	if commit {
-		cmd := exec.Command("git", "add", VrsConfigFileName)
-		cmd.Dir = baseDir
-		err = cmd.Run()
+		err = checkWorkingTree(executor, gitOptions, []string{config.configFile()})
+		if err != nil {
+			return err
+		}
+	}
+	err = config.write(executor)
+	if err != nil {
+		return err
+	}
+	if commit {
+		executor.startBump("", config.Version, nil)
+		commitMessage, err = config.commitMessage(executor, gitOptions, commitMessage)
 		if err != nil {
 			return err
 		}
+		return config.commit(executor, []string{config.configFile()}, push, commitMessage, gitOptions, result)
+	}
+	return nil
+}
 
-		// #nosec - Git commit message is safe to set via variable.
-		cmd = exec.Command("git", "commit", "-m", commitMessage)
-		cmd.Dir = baseDir
-		err = cmd.Run()
+func (config *VrsConfig) write(executor *executor) error {
+	if config.parent != nil {
+		if config.component.Path == "" {
+			config.component.Version = config.Version
+		} else {
+			config.parent.Version = config.Version
+		}
+		return config.parent.write(executor)
+	}
+	originalYml, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, config.configFile()))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	yml, err := config.fileContent(originalYml)
+	if err != nil {
+		return err
+	}
+	return executor.writeFile(config.configFile(), yml)
+}
+
+// Returns path of the config file relative to the project directory.
+func (config *VrsConfig) configFile() string {
+	if config.parent != nil {
+		return config.parent.configFile()
+	}
+	return firstNonEmpty(config.file, VrsConfigFileName)
+}
+
+// Commits given files in a single commit, tags it with the version and optionally pushes commit and tag.
+func (config *VrsConfig) commit(executor *executor, files []string, push bool, commitMessage string, gitOptions *GitOptions, result *BumpResult) error {
+	tag, err := config.tagName(executor, gitOptions)
+	if err != nil {
+		return err
+	}
+	tagOptions, err := config.tagOptions(executor, gitOptions)
+	if err != nil {
+		return err
+	}
+	if push {
+		err = config.checkRemoteTag(executor, gitOptions, tag)
 		if err != nil {
 			return err
 		}
+		err = config.checkReleases(executor)
+		if err != nil {
+			return err
+		}
+	}
 
-		cmd = exec.Command("git", "tag", "v"+config.Version)
-		cmd.Dir = baseDir
-		err = cmd.Run()
+	err = executor.gitAdd(files...)
+	if err != nil {
+		return err
+	}
+
+	err = executor.gitCommit(commitMessage, config.commitOptions(gitOptions))
+	if err != nil {
+		return err
+	}
+	err = result.addCommit(executor)
+	if err != nil {
+		return err
+	}
+
+	err = executor.gitTag(tag, tagOptions)
+	if err != nil {
+		return err
+	}
+	result.Tag = tag
+	for _, dependent := range config.dependents {
+		dependentTag, err := dependent.config.tagName(executor, nil)
 		if err != nil {
 			return err
 		}
+		dependentTagOptions, err := dependent.config.tagOptions(executor, gitOptions)
+		if err != nil {
+			return err
+		}
+		err = executor.gitTag(dependentTag, dependentTagOptions)
+		if err != nil {
+			return err
+		}
+		dependent.result.Tag = dependentTag
+	}
+	err = executor.runHooks("postTag", config.hooks().PostTag)
+	if err != nil {
+		return err
+	}
 
-		if push {
-			cmd = exec.Command("git", "push")
-			cmd.Dir = baseDir
-			err = cmd.Run()
-			if err != nil {
-				return err
-			}
+	if push {
+		remote, branch := config.pushTarget(gitOptions)
+		err = executor.gitPush(remote, branch)
+		if err != nil {
+			return err
+		}
 
-			cmd = exec.Command("git", "push", "--tags")
-			cmd.Dir = baseDir
-			err = cmd.Run()
+		if config.pushTagsWithGit() {
+			err = executor.gitPushTags(remote)
 			if err != nil {
 				return err
 			}
 		}
+		err = config.publishReleases(executor, gitOptions, tag, result.lastCommit(), result)
+		if err != nil {
+			return err
+		}
+		err = config.notify(executor, gitOptions, result)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func (config *VrsConfig) tagVersion() string {
+	if config.Metadata != nil && config.Metadata.Tag {
+		return config.Version
+	}
+	return stripMetadata(config.Version)
+}
+
+func (config *VrsConfig) syncVersion(version string) string {
+	if config.Metadata != nil && config.Metadata.Sync {
+		return version
+	}
+	return stripMetadata(version)
+}
+
 type InitOptions struct {
-	Basedir   string
-	GitCommit bool
-	GitPush   bool
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	GitCommit  bool
+	GitPush    bool
+	DryRun     bool
+	// Output of the dry run report. Defaults to standard output.
+	DryRunOutput io.Writer
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Callbacks notified about the steps of the operation, able to veto them.
+	Events Events
+	GitOptions
 }
 
 func NewDefaultInitOptions() (*InitOptions, error) {
@@ -138,6 +515,11 @@ func NewDefaultInitOptions() (*InitOptions, error) {
 }
 
 func Init(options *InitOptions) error {
+	return InitContext(context.Background(), options)
+}
+
+// Initializes versioon file. Git operations are cancelled when the context is done.
+func InitContext(ctx context.Context, options *InitOptions) error {
 	if options == nil {
 		o, err := NewDefaultInitOptions()
 		if err != nil {
@@ -145,7 +527,12 @@ func Init(options *InitOptions) error {
 		}
 		options = o
 	}
-	err := (&VrsConfig{Version: "0.0.0"}).WriteAndCommit(options.Basedir, options.GitCommit, options.GitPush, "Initialized versioon file.")
+	configPath, err := relativeConfigPath(options.Basedir, options.ConfigPath)
+	if err != nil {
+		return err
+	}
+	executor := newExecutor(ctx, options.Basedir, options.DryRun, options.DryRunOutput, options.GitClient, options.FileSystem, options.Events)
+	err = (&VrsConfig{Version: "0.0.0", file: configPath}).writeAndCommit(executor, options.GitCommit, options.GitPush, "Initialized versioon file.", &options.GitOptions, &BumpResult{})
 	if err != nil {
 		return err
 	}
@@ -153,142 +540,905 @@ func Init(options *InitOptions) error {
 }
 
 type BumpOptions struct {
-	Basedir        string
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to operate on. Empty name selects the project itself.
+	Component      string
 	GitCommit      bool
 	GitPush        bool
 	ActiveProfiles []string
+	// Bumped segment of the version. AutoSegment chooses it from Conventional Commits made since the last release.
+	Segment string
+	// Identifier used when bumping prerelease segment, for example "alpha", "beta" or "rc".
+	PrereleaseIdentifier string
+	// Build metadata attached to the bumped version. Metadata of the previous version is always dropped.
+	Metadata string
+	// Date used to compute calendar versions. Zero value means current time.
+	Date   time.Time
+	DryRun bool
+	// Output of the dry run report. Defaults to standard output.
+	DryRunOutput io.Writer
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Callbacks notified about the steps of the operation, able to veto them.
+	Events Events
+	GitOptions
 }
 
 func NewDefaultBumpOptions() (*BumpOptions, error) {
-	wd, err := os.Getwd()
+	basedir, err := defaultBasedir()
 	if err != nil {
 		return nil, err
 	}
 	return &BumpOptions{
-		Basedir:   wd,
-		GitCommit: true,
-		GitPush:   true,
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		GitCommit:      true,
+		GitPush:        true,
+		Segment:        MinorSegment,
 	}, nil
 }
 
-func Bump(options *BumpOptions) error {
+func Bump(options *BumpOptions) (*BumpResult, error) {
+	return BumpContext(context.Background(), options)
+}
+
+// Bumps version of the project. Git operations and file synchronization are cancelled when the context is done. Changes
+// made before cancellation are rolled back.
+func BumpContext(ctx context.Context, options *BumpOptions) (*BumpResult, error) {
 	if options == nil {
 		o, err := NewDefaultBumpOptions()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		options = o
 	}
 
-	config, err := ParseVersioonConfig(options.Basedir)
+	project, err := parseConfigFile(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	config, err := project.selectComponent(options.FileSystem, options.Basedir, options.Component)
+	if err != nil {
+		return nil, err
+	}
+	if options.Component != "" {
+		config.dependents, err = project.dependentBumps(options.FileSystem, options.Basedir, options.Component)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	scheme, err := config.scheme(options)
+	if err != nil {
+		return nil, err
+	}
+	executor := newExecutor(ctx, options.Basedir, options.DryRun, options.DryRunOutput, options.GitClient, options.FileSystem, options.Events)
+	err = config.readTagVersion(executor, &options.GitOptions)
+	if err != nil {
+		return nil, err
+	}
+	segment := BumpKind(options.Segment)
+	if segment == AutoSegment {
+		segment, err = config.autoSegment(executor, &options.GitOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
 	oldVersion := config.Version
-	versionParts := strings.Split(oldVersion, ".")
-	minorVersion, err := strconv.Atoi(versionParts[1])
+	config.Version, err = scheme.Next(oldVersion, segment)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	config.Version = fmt.Sprintf("%s.%d.%s", versionParts[0], minorVersion+1, versionParts[2])
-	err = config.WriteAndCommit(options.Basedir, options.GitCommit, options.GitPush, "Version bump.")
+	err = scheme.Validate(config.Version)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return config.release(executor, options.GitCommit, options.GitPush, options.ActiveProfiles, &options.GitOptions, oldVersion, "Version bump.")
+}
 
-	if config.Sync != nil {
-		for _, file := range config.Sync.Files {
-			if file.Pattern == "" {
-				err = bumpInFile(options.Basedir, options.GitCommit, file.Name, oldVersion, "", config.Version)
-			} else {
-				err = bumpInFile(options.Basedir, options.GitCommit, file.Name, "", file.Pattern, config.Version)
+type SetOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to operate on. Empty name selects the project itself.
+	Component      string
+	GitCommit      bool
+	GitPush        bool
+	ActiveProfiles []string
+	Version        string
+	DryRun         bool
+	// Output of the dry run report. Defaults to standard output.
+	DryRunOutput io.Writer
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Callbacks notified about the steps of the operation, able to veto them.
+	Events Events
+	GitOptions
+}
+
+func NewDefaultSetOptions() (*SetOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &SetOptions{
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		GitCommit:      true,
+		GitPush:        true,
+	}, nil
+}
+
+// Sets explicit version of the project, for example to jump to the next major release.
+func Set(options *SetOptions) (*BumpResult, error) {
+	return SetContext(context.Background(), options)
+}
+
+func SetContext(ctx context.Context, options *SetOptions) (*BumpResult, error) {
+	if options == nil {
+		o, err := NewDefaultSetOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	version := config.bareVersion(options.Version)
+	err = config.validateVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	oldVersion := config.Version
+	config.Version = version
+	executor := newExecutor(ctx, options.Basedir, options.DryRun, options.DryRunOutput, options.GitClient, options.FileSystem, options.Events)
+	return config.release(executor, options.GitCommit, options.GitPush, options.ActiveProfiles, &options.GitOptions, oldVersion, "Version set.")
+}
+
+type PromoteOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to operate on. Empty name selects the project itself.
+	Component      string
+	GitCommit      bool
+	GitPush        bool
+	ActiveProfiles []string
+	DryRun         bool
+	// Output of the dry run report. Defaults to standard output.
+	DryRunOutput io.Writer
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Callbacks notified about the steps of the operation, able to veto them.
+	Events Events
+	GitOptions
+}
+
+func NewDefaultPromoteOptions() (*PromoteOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &PromoteOptions{
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		GitCommit:      true,
+		GitPush:        true,
+	}, nil
+}
+
+// Promotes prerelease version to the final release, for example 1.5.0-rc.3 becomes 1.5.0.
+func Promote(options *PromoteOptions) (*BumpResult, error) {
+	return PromoteContext(context.Background(), options)
+}
+
+func PromoteContext(ctx context.Context, options *PromoteOptions) (*BumpResult, error) {
+	if options == nil {
+		o, err := NewDefaultPromoteOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := newExecutor(ctx, options.Basedir, options.DryRun, options.DryRunOutput, options.GitClient, options.FileSystem, options.Events)
+	err = config.readTagVersion(executor, &options.GitOptions)
+	if err != nil {
+		return nil, err
+	}
+	version := config.ParsedVersion()
+	if version == nil {
+		return nil, fmt.Errorf("%w %q: only semantic versions can be promoted", ErrInvalidVersion, config.Version)
+	}
+	if version.Prerelease == "" {
+		return nil, fmt.Errorf("version %s is not a prerelease", config.Version)
+	}
+	config.Version = (&Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}).String()
+	return config.release(executor, options.GitCommit, options.GitPush, options.ActiveProfiles, &options.GitOptions, version.String(), "Version promotion.")
+}
+
+// Prerelease identifier of Maven development versions, for example 1.5.0-SNAPSHOT.
+const SnapshotPrerelease = "SNAPSHOT"
+
+type ReleaseOptions struct {
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	GitPush        bool
+	ActiveProfiles []string
+	// Segment bumped to compute the next development version. Patch segment is bumped by default.
+	Segment string
+	DryRun  bool
+	// Output of the dry run report. Defaults to standard output.
+	DryRunOutput io.Writer
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Callbacks notified about the steps of the operation, able to veto them.
+	Events Events
+	GitOptions
+}
+
+func NewDefaultReleaseOptions() (*ReleaseOptions, error) {
+	basedir, err := defaultBasedir()
+	if err != nil {
+		return nil, err
+	}
+	return &ReleaseOptions{
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		GitPush:        true,
+		Segment:        PatchSegment,
+	}, nil
+}
+
+// Releases Maven-style SNAPSHOT version the way maven-release-plugin does. SNAPSHOT suffix is removed and the release
+// is committed and tagged, then the next development version (for example 1.5.1-SNAPSHOT after 1.5.0) is committed in
+// a follow-up commit. Both commits and the tag are pushed at the end.
+func Release(options *ReleaseOptions) (*BumpResult, error) {
+	return ReleaseContext(context.Background(), options)
+}
+
+func ReleaseContext(ctx context.Context, options *ReleaseOptions) (_ *BumpResult, err error) {
+	if options == nil {
+		o, err := NewDefaultReleaseOptions()
+		if err != nil {
+			return nil, err
+		}
+		options = o
+	}
+
+	config, err := parseConfigFile(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	version := config.ParsedVersion()
+	if version == nil || version.Prerelease != SnapshotPrerelease {
+		return nil, fmt.Errorf("version %s is not a %s version", config.Version, SnapshotPrerelease)
+	}
+	releaseVersion := &Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch}
+	developmentVersion, err := releaseVersion.Bump(BumpKind(firstNonEmpty(options.Segment, PatchSegment)))
+	if err != nil {
+		return nil, err
+	}
+	developmentVersion.Prerelease = SnapshotPrerelease
+	gitPush := config.pushEnabled(options.GitPush)
+
+	executor := newExecutor(ctx, options.Basedir, options.DryRun, options.DryRunOutput, options.GitClient, options.FileSystem, options.Events)
+	err = verifyGitRepository(executor)
+	if err != nil {
+		return nil, err
+	}
+	activeProfiles := config.activeProfiles(executor, options.ActiveProfiles)
+	config.Version = releaseVersion.String()
+	if gitPush {
+		tag, err := config.tagName(executor, &options.GitOptions)
+		if err != nil {
+			return nil, err
+		}
+		err = config.checkRemoteTag(executor, &options.GitOptions, tag)
+		if err != nil {
+			return nil, err
+		}
+		err = config.checkReleases(executor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := config.release(executor, true, false, options.ActiveProfiles, &options.GitOptions, version.String(), "Version release.")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			err = executor.rollback(err)
+		}
+	}()
+	config.Version = developmentVersion.String()
+	result.DevelopmentVersion = config.Version
+	executor.startBump(releaseVersion.String(), config.Version, activeProfiles)
+	err = executor.events.OnVersionComputed(executor.ctx, releaseVersion.String(), config.Version)
+	if err != nil {
+		return nil, err
+	}
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would change version %s to %s.\n", releaseVersion, config.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = config.write(executor)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{config.configFile()}
+	for _, sync := range config.activeSyncs(activeProfiles) {
+		syncedFileCount := len(result.SyncedFiles)
+		err = syncFiles(executor, sync, config.syncVersion(releaseVersion.String()), config.syncVersion(config.Version), result)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range result.SyncedFiles[syncedFileCount:] {
+			if file.Replacements > 0 {
+				files = append(files, file.Name)
 			}
+		}
+	}
+	commitMessage, err := config.commitMessage(executor, &options.GitOptions, "Next development version.")
+	if err != nil {
+		return nil, err
+	}
+	err = executor.gitAdd(files...)
+	if err != nil {
+		return nil, err
+	}
+	err = executor.gitCommit(commitMessage, config.commitOptions(&options.GitOptions))
+	if err != nil {
+		return nil, err
+	}
+	err = result.addCommit(executor)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitPush {
+		remote, branch := config.pushTarget(&options.GitOptions)
+		err = executor.gitPush(remote, branch)
+		if err != nil {
+			return nil, err
+		}
+		if config.pushTagsWithGit() {
+			err = executor.gitPushTags(remote)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
+		config.Version = releaseVersion.String()
+		releaseCommit := ""
+		if len(result.Commits) > 0 {
+			releaseCommit = result.Commits[0]
+		}
+		err = config.publishReleases(executor, &options.GitOptions, result.Tag, releaseCommit, result)
+		if err != nil {
+			return nil, err
+		}
+		err = config.notify(executor, &options.GitOptions, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Writes new version into config file and synchronizes it with other files of the project. If any step fails, changes
+// made so far are rolled back.
+func (config *VrsConfig) release(executor *executor, gitCommit bool, gitPush bool, activeProfiles []string, gitOptions *GitOptions, previousVersion string, commitMessage string) (_ *BumpResult, err error) {
+	defer func() {
+		if err != nil {
+			err = executor.rollback(err)
+		}
+	}()
+
+	executor.fileMode, err = config.fileMode()
+	if err != nil {
+		return nil, err
+	}
+	gitPush = config.pushEnabled(gitPush)
+	activeProfiles = config.activeProfiles(executor, activeProfiles)
+	result := &BumpResult{OldVersion: previousVersion, NewVersion: config.Version}
+	executor.startBump(previousVersion, config.Version, activeProfiles)
+	err = executor.events.OnVersionComputed(executor.ctx, previousVersion, config.Version)
+	if err != nil {
+		return nil, err
+	}
+	commitMessage, err = config.commitMessage(executor, gitOptions, commitMessage)
+	if err != nil {
+		return nil, err
+	}
+	if gitCommit {
+		gitCommit, err = config.gitAvailable(executor, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if gitCommit {
+		err = checkWorkingTree(executor, gitOptions, config.syncFileNames(activeProfiles))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if executor.dryRun {
+		_, err := fmt.Fprintf(executor.output, "Would change version %s to %s.\n", previousVersion, config.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = executor.runHooks("preBump", config.hooks().PreBump)
+	if err != nil {
+		return nil, err
+	}
+	err = config.write(executor)
+	if err != nil {
+		return nil, err
+	}
+
+	oldVersion := config.syncVersion(previousVersion)
+	newVersion, err := config.withBuildNumber(config.syncVersion(config.Version))
+	if err != nil {
+		return nil, err
+	}
+	executor.buildNumber = config.BuildNumber
+	for _, sync := range config.activeSyncs(activeProfiles) {
+		err = syncFiles(executor, sync, oldVersion, newVersion, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, dependent := range config.dependents {
+		err = dependent.release(executor, activeProfiles)
+		if err != nil {
+			return nil, err
+		}
+		result.Dependents = append(result.Dependents, dependent.result)
+	}
+	changelogFile, err := config.writeChangelog(executor, gitOptions)
+	if err != nil {
+		return nil, err
+	}
+	err = executor.runHooks("postBump", config.hooks().PostBump)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitCommit {
+		files := append([]string{config.configFile()}, result.changedFiles()...)
+		for _, dependent := range config.dependents {
+			files = append(files, dependent.config.configFile())
+			files = append(files, dependent.result.changedFiles()...)
+		}
+		if changelogFile != "" {
+			files = append(files, changelogFile)
+		}
+		err = config.commit(executor, files, gitPush, commitMessage, gitOptions, result)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	return result, nil
+}
+
+// Returns sync settings of the config and of the active profiles.
+func (config *VrsConfig) activeSyncs(activeProfiles []string) []*Sync {
+	var syncs []*Sync
+	if config.Sync != nil {
+		syncs = append(syncs, config.Sync)
+	}
 	for _, profile := range config.Profiles {
-		for _, activeProfile := range options.ActiveProfiles {
+		for _, activeProfile := range activeProfiles {
 			if activeProfile == profile.Name {
 				if profile.Sync != nil {
-					for _, file := range profile.Sync.Files {
-						if file.Pattern == "" {
-							err = bumpInFile(options.Basedir, options.GitCommit, file.Name, oldVersion, "", config.Version)
-						} else {
-							err = bumpInFile(options.Basedir, options.GitCommit, file.Name, "", file.Pattern, config.Version)
-						}
-						if err != nil {
-							return err
-						}
-					}
+					syncs = append(syncs, profile.Sync)
 				}
 				break
 			}
 		}
 	}
-
-	return nil
+	return syncs
 }
 
-func bumpInFile(baseDir string, gitCommit bool, file string, oldVersion string, oldExpression string, newVersion string) error {
-	filePath := path.Join(baseDir, file)
-	originalBytes, err := os.ReadFile(filePath)
-	if err != nil {
-		return err
+// Returns names of all files managed by vrs, including vrs.yml itself.
+func (config *VrsConfig) syncFileNames(activeProfiles []string) []string {
+	names := []string{config.configFile()}
+	for _, dependent := range config.dependents {
+		names = append(names, dependent.config.syncFileNames(activeProfiles)...)
+	}
+	if config.Sync != nil {
+		for _, file := range config.Sync.Files {
+			name, _ := file.namePattern()
+			names = append(names, name)
+		}
+	}
+	for _, profile := range config.Profiles {
+		for _, activeProfile := range activeProfiles {
+			if activeProfile == profile.Name && profile.Sync != nil {
+				for _, file := range profile.Sync.Files {
+					name, _ := file.namePattern()
+					names = append(names, name)
+				}
+			}
+		}
 	}
-	bumpedFile := ""
-	if oldVersion != "" {
-		bumpedFile = strings.ReplaceAll(string(originalBytes), oldVersion, newVersion)
-	} else {
-		r, err := regexp.Compile(oldExpression)
+	return names
+}
+
+// Update of the synced file. Updates are planned sequentially and executed concurrently, grouped by file.
+type syncStep struct {
+	// Sync file configuration of the step. Nil for companion files.
+	file *SyncFile
+	name string
+	// Name or glob pattern which selected the file.
+	namePattern string
+	update      contentUpdater
+	// Replacement applied to large files without loading them into memory. Nil if the step can't be streamed.
+	literal *literalReplacement
+	// Companion files are updated only if they exist.
+	companion bool
+
+	replacements int
+	sizeDiff     int
+	skipped      bool
+	warning      string
+	err          error
+}
+
+// Synced file with all the updates of its content.
+type syncedContent struct {
+	name     string
+	steps    []*syncStep
+	original []byte
+	content  []byte
+	// Offsets of the literal replacement of large file streamed instead of being loaded into memory.
+	streamed bool
+	offsets  []int64
+}
+
+func syncFiles(executor *executor, syncConfig *Sync, oldVersion string, newVersion string, result *BumpResult) error {
+	var steps []*syncStep
+	for i := range syncConfig.Files {
+		file := &syncConfig.Files[i]
+		namePattern, err := file.namePattern()
 		if err != nil {
 			return err
 		}
-		bumpedFile = r.ReplaceAllString(string(originalBytes), newVersion)
+		names, err := expandSyncFileName(executor, namePattern, syncConfig.Exclude)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			update, err := file.updater(executor, name, oldVersion, newVersion)
+			if err != nil {
+				return err
+			}
+			literal, err := file.literalReplacement(executor, oldVersion, newVersion)
+			if err != nil {
+				return err
+			}
+			steps = append(steps, &syncStep{file: file, name: name, namePattern: namePattern, update: update, literal: literal})
+			companions, err := file.companions(executor, name, newVersion)
+			if err != nil {
+				return err
+			}
+			for _, companion := range companions {
+				steps = append(steps, &syncStep{name: companion.name, update: companion.update, companion: true})
+			}
+		}
 	}
 
-	err = os.WriteFile(filePath, []byte(bumpedFile), 0600)
-	if err != nil {
-		return err
+	// Updates of the same file are applied one after another, different files are updated concurrently.
+	var contents []*syncedContent
+	contentsByName := map[string]*syncedContent{}
+	for _, step := range steps {
+		content, ok := contentsByName[path.Clean(step.name)]
+		if !ok {
+			content = &syncedContent{name: step.name}
+			contentsByName[path.Clean(step.name)] = content
+			contents = append(contents, content)
+		}
+		content.steps = append(content.steps, step)
 	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(contents) {
+		workers = len(contents)
+	}
+	queue := make(chan *syncedContent)
+	wait := &sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for content := range queue {
+				content.update(executor)
+			}
+		}()
+	}
+	for _, content := range contents {
+		queue <- content
+	}
+	close(queue)
+	wait.Wait()
 
-	if gitCommit {
-		cmd := exec.Command("git", "add", file)
-		cmd.Dir = baseDir
-		err = cmd.Run()
+	var errs []error
+	for _, step := range steps {
+		switch {
+		case step.err != nil:
+			errs = append(errs, step.err)
+		case step.file != nil && step.file.Required && !step.skipped && step.replacements == 0:
+			errs = append(errs, fmt.Errorf("%w: file %s, pattern %s", ErrSyncPatternNotMatched, step.name, step.file.selector(oldVersion)))
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 1 {
+		return &SyncError{Errors: errs}
+	}
+
+	syncedFileCount := len(result.SyncedFiles)
+	for _, step := range steps {
+		if step.warning != "" {
+			result.Warnings = append(result.Warnings, step.warning)
+		}
+		if !step.skipped {
+			result.SyncedFiles = append(result.SyncedFiles, &SyncedFile{Name: step.name, Path: path.Join(executor.baseDir, step.name), Replacements: step.replacements, SizeDiff: step.sizeDiff})
+		}
+	}
+	for _, content := range contents {
+		if content.streamed && len(content.offsets) > 0 {
+			err := executor.rewriteFile(content.name, content.offsets, content.steps[0].literal)
+			if err != nil {
+				return err
+			}
+		}
+		if content.content != nil && !bytes.Equal(content.content, content.original) {
+			err := executor.writeFile(content.name, content.content)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, file := range result.SyncedFiles[syncedFileCount:] {
+		err := executor.events.OnFileSynced(executor.ctx, file)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		cmd = exec.Command("git", "commit", "-m", "Bumped version.")
-		cmd.Dir = baseDir
-		err = cmd.Run()
+// Reads the file and applies its updates in memory. Problems are recorded in the steps.
+func (content *syncedContent) update(executor *executor) {
+	fail := func(err error) {
+		for _, step := range content.steps {
+			step.err = err
+		}
+	}
+	if err := executor.ctx.Err(); err != nil {
+		fail(err)
+		return
+	}
+	streamed, err := content.stream(executor)
+	if err != nil && !os.IsNotExist(err) {
+		fail(err)
+		return
+	}
+	if streamed {
+		return
+	}
+	original, err := executor.fileSystem.ReadFile(path.Join(executor.baseDir, content.name))
+	if os.IsNotExist(err) {
+		for _, step := range content.steps {
+			if step.companion {
+				step.skipped = true
+			} else {
+				step.err = fmt.Errorf("%w: %s", ErrSyncFileMissing, content.name)
+			}
+		}
+		return
+	}
+	if err != nil {
+		fail(err)
+		return
+	}
+	if isBinary(original) {
+		content.skipBinary()
+		return
+	}
+
+	updated := original
+	for _, step := range content.steps {
+		bumped, replacements, err := step.update(updated)
 		if err != nil {
-			return err
+			step.err = fmt.Errorf("cannot sync file %s: %w", content.name, err)
+			return
+		}
+		if step.file == nil || !step.file.definesContent() {
+			bumped = preserveLineEndings(updated, bumped)
+		}
+		step.replacements, step.sizeDiff = replacements, len(bumped)-len(updated)
+		updated = bumped
+	}
+	content.original, content.content = original, updated
+}
+
+// Finds occurrences of the version in large file without loading it into memory. Returns false if the file can't be
+// streamed, because it is small, file system doesn't support streaming or the file has other than literal updates.
+func (content *syncedContent) stream(executor *executor) (bool, error) {
+	fileSystem, ok := executor.fileSystem.(StreamingFileSystem)
+	if !ok || len(content.steps) != 1 || content.steps[0].literal == nil {
+		return false, nil
+	}
+	filePath := path.Join(executor.baseDir, content.name)
+	size, err := fileSystem.Size(filePath)
+	if err != nil || size <= streamingThreshold {
+		return false, err
+	}
+	reader, err := fileSystem.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+	bufferedReader := bufio.NewReaderSize(reader, streamingChunkSize)
+	head, err := bufferedReader.Peek(8000)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	if isBinary(head) {
+		content.skipBinary()
+		return true, nil
+	}
+	step := content.steps[0]
+	content.offsets, err = step.literal.find(bufferedReader)
+	if err != nil {
+		return false, err
+	}
+	content.streamed = true
+	step.replacements, step.sizeDiff = len(content.offsets), len(content.offsets)*(len(step.literal.new)-len(step.literal.old))
+	return true, nil
+}
+
+// Skips binary file matched by glob pattern. Other binary files are reported as errors.
+func (content *syncedContent) skipBinary() {
+	for _, step := range content.steps {
+		if !step.companion && isGlob(step.namePattern) {
+			step.skipped = true
+			step.warning = fmt.Sprintf("skipped binary file %s matching %s", content.name, step.namePattern)
+		} else {
+			step.err = fmt.Errorf("%w: %s", ErrBinarySyncFile, content.name)
 		}
 	}
+}
+
+// Detects binary content the way git does, by looking for NUL byte at the beginning of the content.
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+type BumpResult struct {
+	OldVersion  string        `json:"oldVersion"`
+	NewVersion  string        `json:"newVersion"`
+	SyncedFiles []*SyncedFile `json:"syncedFiles,omitempty"`
+	// Name of the created git tag. Empty if changes have not been committed.
+	Tag string `json:"tag,omitempty"`
+	// SHAs of the created git commits.
+	Commits []string `json:"commits,omitempty"`
+	// Development version committed after the release by Release. Empty for other operations.
+	DevelopmentVersion string `json:"developmentVersion,omitempty"`
+	// Problems which haven't stopped the operation, for example binary files skipped by the sync.
+	Warnings []string `json:"warnings,omitempty"`
+	// URL of the release created in git hosting service. Empty if no release has been created.
+	ReleaseURL string `json:"releaseUrl,omitempty"`
+	// Bumps of the components depending on the bumped one.
+	Dependents []*BumpResult `json:"dependents,omitempty"`
+}
+
+type SyncedFile struct {
+	Name string `json:"name"`
+	// Path of the file within the file system of the project.
+	Path         string `json:"path"`
+	Replacements int    `json:"replacements"`
+	// Difference between the size of the synced and the original content in bytes.
+	SizeDiff int `json:"sizeDiff"`
+}
 
+// Returns names of the synced files changed by the bump.
+func (result *BumpResult) changedFiles() []string {
+	var files []string
+	for _, file := range result.SyncedFiles {
+		if file.Replacements > 0 {
+			files = append(files, file.Name)
+		}
+	}
+	return files
+}
+
+func (result *BumpResult) lastCommit() string {
+	if len(result.Commits) == 0 {
+		return ""
+	}
+	return result.Commits[len(result.Commits)-1]
+}
+
+func (result *BumpResult) addCommit(executor *executor) error {
+	sha, err := executor.headCommit()
+	if err != nil {
+		return err
+	}
+	if sha != "" {
+		result.Commits = append(result.Commits, sha)
+	}
 	return nil
 }
 
 type ReadCurrentOptions struct {
-	Basedir   string
+	Basedir string
+	// Path of the config file relative to the project directory or absolute. Defaults to the first existing file
+	// of ConfigFileNames.
+	ConfigPath string
+	// User config file providing defaults of all the projects. Defaults to UserConfigFile(). Empty path disables the
+	// user config.
+	UserConfigFile string
+	// Name of the component of the project to operate on. Empty name selects the project itself.
+	Component string
 	GitCommit bool
 	GitPush   bool
+	// File system of the project. Defaults to the local disk.
+	FileSystem FileSystem
+	// Client reading release tags of projects with versions sourced from tags. Defaults to GoGitClient.
+	GitClient GitClient
 }
 
 func NewDefaultReadCurrentOptions() (*ReadCurrentOptions, error) {
-	wd, err := os.Getwd()
+	basedir, err := defaultBasedir()
 	if err != nil {
 		return nil, err
 	}
 	return &ReadCurrentOptions{
-		Basedir:   wd,
-		GitCommit: true,
-		GitPush:   true,
+		Basedir:        basedir,
+		UserConfigFile: defaultUserConfigFile(),
+		GitCommit:      true,
+		GitPush:        true,
 	}, nil
 }
 
@@ -301,9 +1451,9 @@ func ReadCurrentVersion(options *ReadCurrentOptions) (string, error) {
 		options = o
 	}
 
-	config, err := ParseVersioonConfig(options.Basedir)
+	config, err := parseComponentConfig(options.FileSystem, options.Basedir, options.ConfigPath, options.UserConfigFile, options.Component)
 	if err != nil {
 		return "", err
 	}
-	return config.Version, nil
+	return config.currentVersion(context.Background(), options.Basedir, options.GitClient, options.FileSystem)
 }
@@ -1,11 +1,16 @@
 package vrs_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"github.com/hekonsek/vrs/vrs"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
+	"os"
 	"os/exec"
+	"path"
 	"testing"
 )
 
@@ -52,7 +57,7 @@ func TestVersionBump(t *testing.T) {
 	options := &vrs.BumpOptions{Basedir: basedir, GitCommit: false}
 
 	// When
-	err = vrs.Bump(options)
+	_, err = vrs.Bump(options)
 
 	// Then
 	assert.NoError(t, err)
@@ -81,7 +86,7 @@ func TestVersionBumpWithCommit(t *testing.T) {
 	options := &vrs.BumpOptions{Basedir: basedir, GitCommit: true, GitPush: false}
 
 	// When
-	err = vrs.Bump(options)
+	_, err = vrs.Bump(options)
 
 	// Then
 	assert.NoError(t, err)
@@ -93,3 +98,565 @@ func TestVersionBumpWithCommit(t *testing.T) {
 	assert.Equal(t, "0.1.0", version)
 
 }
+
+func TestVersionBumpMajor(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	options := &vrs.BumpOptions{Basedir: basedir, Segment: vrs.MajorSegment}
+
+	// When
+	_, err = vrs.Bump(options)
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestVersionBumpPatch(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	options := &vrs.BumpOptions{Basedir: basedir, Segment: vrs.PatchSegment}
+
+	// When
+	_, err = vrs.Bump(options)
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.1", version)
+}
+
+func TestVersionBumpUnknownSegment(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	options := &vrs.BumpOptions{Basedir: basedir, Segment: "micro"}
+
+	// When
+	_, err = vrs.Bump(options)
+
+	// Then
+	assert.Error(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0", version)
+}
+
+func TestVersionBumpResetsLowerSegments(t *testing.T) {
+	for segment, expectedVersion := range map[string]string{
+		vrs.MajorSegment: "2.0.0",
+		vrs.MinorSegment: "1.3.0",
+		vrs.PatchSegment: "1.2.4",
+	} {
+		// Given
+		basedir, err := ioutil.TempDir("", "ver-test-*")
+		assert.NoError(t, err)
+		err = (&vrs.VrsConfig{Version: "1.2.3"}).Write(basedir)
+		assert.NoError(t, err)
+
+		// When
+		_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Segment: segment})
+
+		// Then
+		assert.NoError(t, err)
+		version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedVersion, version)
+	}
+}
+
+func TestVersionBumpPrerelease(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.4.0-rc.2"}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Segment: vrs.PrereleaseSegment})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.4.0-rc.3", version)
+}
+
+func TestVersionBumpWithMetadata(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.2.3+build.1", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.2.3"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Segment: vrs.PatchSegment, Metadata: "build.2"})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4+build.2", version)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4", string(syncedVersion))
+}
+
+func TestVersionBumpWithMetadataSynced(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.2.3", Metadata: &vrs.Metadata{Sync: true}, Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.2.3"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, Segment: vrs.PatchSegment, Metadata: "build.2"})
+
+	// Then
+	assert.NoError(t, err)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.4+build.2", string(syncedVersion))
+}
+
+func TestPromote(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.5.0-rc.3", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.5.0-rc.3"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Promote(&vrs.PromoteOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", version)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", string(syncedVersion))
+}
+
+func TestPromoteFinalRelease(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.5.0"}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Promote(&vrs.PromoteOptions{Basedir: basedir})
+
+	// Then
+	assert.Error(t, err)
+}
+
+func TestSetVersion(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.5.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.5.0"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Set(&vrs.SetOptions{Basedir: basedir, Version: "2.0.0"})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", version)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.0", string(syncedVersion))
+}
+
+func TestSetInvalidVersion(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.5.0"}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Set(&vrs.SetOptions{Basedir: basedir, Version: "2.0"})
+
+	// Then
+	assert.Error(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5.0", version)
+}
+
+func TestVersionBumpDryRun(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.2.3", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}, {Name: "README"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.2.3"), 0600)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "README"), []byte("No version here."), 0600)
+	assert.NoError(t, err)
+	output := &bytes.Buffer{}
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true, DryRun: true, DryRunOutput: output})
+
+	// Then
+	assert.NoError(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", version)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", string(syncedVersion))
+	assert.Contains(t, output.String(), "Would change version 1.2.3 to 1.3.0.")
+	assert.Contains(t, output.String(), "Would write file VERSION.")
+	assert.Contains(t, output.String(), "Would execute: git tag v1.3.0")
+	assert.NotContains(t, output.String(), "README")
+}
+
+func TestInitDryRun(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	output := &bytes.Buffer{}
+
+	// When
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir, DryRun: true, DryRunOutput: output})
+
+	// Then
+	assert.NoError(t, err)
+	_, err = vrs.ParseVersioonConfig(basedir)
+	assert.Equal(t, vrs.NoVersioonFileFound, err)
+	assert.Equal(t, "Would write file vrs.yml.\n", output.String())
+}
+
+func TestVersionBumpResult(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", basedir).Run()
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.2.3", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.2.3 1.2.3"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", result.OldVersion)
+	assert.Equal(t, "1.3.0", result.NewVersion)
+	assert.Equal(t, "v1.3.0", result.Tag)
+	assert.Equal(t, []*vrs.SyncedFile{{Name: "VERSION", Path: path.Join(basedir, "VERSION"), Replacements: 2}}, result.SyncedFiles)
+	assert.Len(t, result.Commits, 1)
+}
+
+func TestVersionBumpCreatesSingleCommit(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", basedir).Run()
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir, GitCommit: true})
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "0.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}, {Name: "README"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("0.0.0"), 0600)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "README"), []byte("Version 0.0.0"), 0600)
+	assert.NoError(t, err)
+	cmd := exec.Command("git", "add", ".")
+	cmd.Dir = basedir
+	assert.NoError(t, cmd.Run())
+	cmd = exec.Command("git", "commit", "-m", "Sync files.")
+	cmd.Dir = basedir
+	assert.NoError(t, cmd.Run())
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.NoError(t, err)
+	cmd = exec.Command("git", "show", "--name-only", "--format=%s", "v0.1.0")
+	cmd.Dir = basedir
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "Version bump.\n\nREADME\nVERSION\nvrs.yml\n", string(output))
+	cmd = exec.Command("git", "status", "--porcelain")
+	cmd.Dir = basedir
+	output, err = cmd.Output()
+	assert.NoError(t, err)
+	assert.Empty(t, string(output))
+}
+
+func TestVersionBumpRollbackOnMissingSyncFile(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	config := &vrs.VrsConfig{Version: "1.2.3", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}, {Name: "MISSING"}}}}
+	err = config.Write(basedir)
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.2.3"), 0600)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.Error(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", version)
+	syncedVersion, err := ioutil.ReadFile(path.Join(basedir, "VERSION"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", string(syncedVersion))
+}
+
+func TestVersionBumpRollbackOnGitFailure(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = exec.Command("git", "init", basedir).Run()
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir, GitCommit: true})
+	assert.NoError(t, err)
+	cmd := exec.Command("git", "tag", "v0.1.0")
+	cmd.Dir = basedir
+	assert.NoError(t, cmd.Run())
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir, GitCommit: true})
+
+	// Then
+	assert.Error(t, err)
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0", version)
+	cmd = exec.Command("git", "rev-list", "--count", "HEAD")
+	cmd.Dir = basedir
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n", string(output))
+	cmd = exec.Command("git", "status", "--porcelain")
+	cmd.Dir = basedir
+	output, err = cmd.Output()
+	assert.NoError(t, err)
+	assert.Empty(t, string(output))
+}
+
+func TestBumpWithCancelledContext(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = vrs.Init(&vrs.InitOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	_, err = vrs.BumpContext(ctx, &vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.True(t, errors.Is(err, context.Canceled))
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0", version)
+}
+
+func TestBumpWithMissingSyncFile(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "missing.txt"}}}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrSyncFileMissing))
+	assert.Contains(t, err.Error(), "missing.txt")
+}
+
+func TestFailWhenRequiredSyncPatternMatchesNothing(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "version.txt"), []byte("version=0.9.0\n"), 0600)
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "version.txt", Required: true}}}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.True(t, errors.Is(err, vrs.ErrSyncPatternNotMatched))
+	assert.Contains(t, err.Error(), "version.txt")
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: basedir})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+}
+
+func TestSyncReportSizeDiff(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	err = ioutil.WriteFile(path.Join(basedir, "VERSION"), []byte("1.9.0 1.9.0"), 0600)
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.9.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "VERSION"}}}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.SyncedFiles[0].Replacements)
+	assert.Equal(t, 2, result.SyncedFiles[0].SizeDiff)
+}
+
+func TestSyncFilesMatchingGlob(t *testing.T) {
+	// Given
+	basedir, err := ioutil.TempDir("", "ver-test-*")
+	assert.NoError(t, err)
+	for _, dir := range []string{"charts/a", "charts/b/nested"} {
+		err = os.MkdirAll(path.Join(basedir, dir), 0700)
+		assert.NoError(t, err)
+		err = ioutil.WriteFile(path.Join(basedir, dir, "Chart.yaml"), []byte("version: 1.0.0\n"), 0600)
+		assert.NoError(t, err)
+	}
+	err = ioutil.WriteFile(path.Join(basedir, "charts", "values.yaml"), []byte("version: 1.0.0\n"), 0600)
+	assert.NoError(t, err)
+	err = (&vrs.VrsConfig{Version: "1.0.0", Sync: &vrs.Sync{Files: []vrs.SyncFile{{Name: "charts/**/Chart.yaml"}}}}).Write(basedir)
+	assert.NoError(t, err)
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: basedir})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Len(t, result.SyncedFiles, 2)
+	assert.Equal(t, "charts/a/Chart.yaml", result.SyncedFiles[0].Name)
+	assert.Equal(t, "charts/b/nested/Chart.yaml", result.SyncedFiles[1].Name)
+	values, err := ioutil.ReadFile(path.Join(basedir, "charts", "values.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 1.0.0\n", string(values))
+}
+
+func TestBumpDotfileConfig(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{".vrs.yml": []byte("version: 1.0.0\n")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 1.1.0\n", readInMemory(t, fileSystem, ".vrs.yml"))
+}
+
+func TestBumpConfigWithExplicitPath(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml":          []byte("version: 1.0.0\n"),
+		"deploy/chart.yml": []byte("version: 0.3.0\n"),
+	})
+	git := &recordingGitClient{}
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", ConfigPath: "deploy/chart.yml", GitCommit: true, FileSystem: fileSystem,
+		GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "version: 0.4.0\n", readInMemory(t, fileSystem, "deploy/chart.yml"))
+	assert.Equal(t, "version: 1.0.0\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestPreserveConfigCommentsOnBump(t *testing.T) {
+	// Given
+	config := "# Release settings of the app\nsync:\n  files:\n  - name: VERSION # plain text file\n\n" +
+		"version: \"1.0.0\" # bumped by CI\ncomponents:\n- name: api\n  version: 2.0.0\n"
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{"vrs.yml": []byte(config), "VERSION": []byte("1.0.0")})
+
+	// When
+	_, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	_, err = vrs.Bump(&vrs.BumpOptions{Basedir: ".", Component: "api", Segment: vrs.PatchSegment, FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "# Release settings of the app\nsync:\n  files:\n  - name: VERSION # plain text file\n\n"+
+		"version: \"1.1.0\" # bumped by CI\ncomponents:\n- name: api\n  version: 2.0.1\n", readInMemory(t, fileSystem, "vrs.yml"))
+}
+
+func TestBumpPrefixedVersion(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("version: v1.2.3\nsync:\n  files:\n  - name: VERSION\n"),
+		"VERSION": []byte("v1.2.3"),
+	})
+	git := &recordingGitClient{}
+
+	// When
+	result, err := vrs.Bump(&vrs.BumpOptions{Basedir: ".", GitCommit: true, FileSystem: fileSystem, GitOptions: vrs.GitOptions{GitClient: git}})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3", result.OldVersion)
+	assert.Equal(t, "1.3.0", result.NewVersion)
+	assert.Equal(t, "v1.3.0", result.Tag)
+	assert.Equal(t, "version: v1.3.0\nsync:\n  files:\n  - name: VERSION\n", readInMemory(t, fileSystem, "vrs.yml"))
+	assert.Equal(t, "v1.3.0", readInMemory(t, fileSystem, "VERSION"))
+}
+
+func TestSetVersionWithConfiguredPrefix(t *testing.T) {
+	// Given
+	fileSystem := vrs.NewMemoryFileSystem(map[string][]byte{
+		"vrs.yml": []byte("versionPrefix: v\nversion: 1.0.0\ncomponents:\n- name: api\n  version: 2.0.0\n"),
+	})
+
+	// When
+	_, err := vrs.Set(&vrs.SetOptions{Basedir: ".", Version: "v1.1.0", FileSystem: fileSystem})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "versionPrefix: v\nversion: v1.1.0\ncomponents:\n- name: api\n  version: v2.0.0\n", readInMemory(t, fileSystem, "vrs.yml"))
+	version, err := vrs.ReadCurrentVersion(&vrs.ReadCurrentOptions{Basedir: ".", FileSystem: fileSystem})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", version)
+}